@@ -0,0 +1,197 @@
+// Package cmd is the bt command: its flags and non-interactive commands, the first run wizard,
+// and starting the terminal UI.
+package cmd
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"runtime/debug"
+
+	"github.com/seven-serverless-projects/bt/model"
+	"github.com/seven-serverless-projects/bt/parse"
+	"github.com/seven-serverless-projects/bt/storage"
+	"github.com/seven-serverless-projects/bt/tui"
+)
+
+// BT - BubbleTimer's configuration for a run of the bt command
+type BT struct {
+	config     model.Config // the config with the profile's fields in effect
+	configFile string
+	profile    string // blank for the top level profile of the config
+	dataDir    string
+}
+
+// Main - run the bt command with the command line's flags and arguments
+func Main() {
+	var ui *tui.UI
+	// Last resort, restore the terminal before reporting an unexpected crash
+	defer func() {
+		if r := recover(); r != nil {
+			ui.Stop()
+			fmt.Printf("\nbt crashed: %v\n\n%s", r, debug.Stack())
+			os.Exit(1)
+		}
+	}()
+
+	configFlag := flag.String("config", "", "path of the config file, overrides $BT_CONFIG")
+	profileFlag := flag.String("profile", "", "name of the profile in the config file to use")
+	flag.Usage = func() {
+		fmt.Println("Usage: bt [-config <file>] [-profile <name>] [import ... | gaps ... | goals ... | config check]")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	bt := BT{}
+	var err error
+	var legacyFile string
+	bt.configFile, legacyFile, err = storage.ConfigFilePath(*configFlag)
+	exitOnError(err)
+	if legacyFile != "" {
+		fmt.Printf("\nMoved your config file from %s to %s\n", legacyFile, bt.configFile)
+	}
+	if flag.NArg() > 0 && flag.Arg(0) == "config" {
+		// Before resolving the profile, so a wrong profile name is reported with every other problem
+		err = configCommand(flag.Args(), bt.configFile, *profileFlag)
+		fmt.Println("Come back soon!")
+		exitOnError(err)
+		return
+	}
+	bt.dataDir, err = storage.DataDirPath()
+	exitOnError(err)
+	conf, err := getConfig(bt.configFile)
+	exitOnError(err)
+	bt.profile, err = model.ResolveProfileName(conf, *profileFlag)
+	exitOnError(err)
+	bt.config = model.ProfileConfig(conf, bt.profile)
+	if flag.NArg() > 0 {
+		err = bt.runCommand(flag.Args())
+		fmt.Println("Come back soon!")
+		exitOnError(err)
+		return
+	}
+	store, err := bt.connect(bt.config, bt.profile)
+	exitOnError(err)
+	ui, err = tui.New(tui.Options{
+		Config:     bt.config,
+		ConfigFile: bt.configFile,
+		Profile:    bt.profile,
+		DataDir:    bt.dataDir,
+		Store:      store,
+		Connect:    bt.connect,
+		Clock:      model.SystemClock{},
+	})
+	if err != nil {
+		store.Close()
+		exitOnError(err)
+	}
+	err = ui.Run() // Blocking
+	ui.Close()
+	fmt.Println("Come back soon!")
+	exitOnError(err)
+}
+
+// Refuse to run with the named profile if it has fatal problems, otherwise return the store
+// of the profile's days in Firestore. The name is the profile's, not bt.profile, as the UI
+// connects to the profile it switches to.
+func (bt *BT) connect(conf model.Config, profile string) (storage.Store, error) {
+	if model.FatalProblems(model.ValidateConfig(model.Config{Profile: conf.Profile})) {
+		return nil, fmt.Errorf("the %s profile in the config file at %s has errors that must be fixed, see: bt config check",
+			model.ProfileDisplayName(profile), bt.configFile)
+	}
+	return storage.NewFirestoreStore(context.Background(), conf.ProjectID, conf.UserID)
+}
+
+// Run the config command from the command line, bt config check, checking the config file
+// and the profile named with -profile, if there is one
+func configCommand(args []string, configFile string, profile string) error {
+	if len(args) != 2 || args[1] != "check" {
+		fmt.Println("Usage: bt config check")
+		os.Exit(2)
+	}
+	conf, err := storage.ReadConfig(configFile)
+	if err != nil {
+		return err
+	}
+	return checkConfig(conf, configFile, profile)
+}
+
+// Report the error, if there is one, and exit
+func exitOnError(err error) {
+	if err != nil {
+		fmt.Printf("\nError: %v\n\n", err)
+		os.Exit(1)
+	}
+}
+
+// Run a non-interactive command from the command line, e.g. bt import toggl export.csv
+func (bt *BT) runCommand(args []string) error {
+	switch args[0] {
+	case "import":
+		flags := flag.NewFlagSet("import", flag.ExitOnError)
+		rounding := flags.String("rounding", parse.RoundNearest, "rounding rule for partial time slices: nearest, expand or shrink")
+		flags.Usage = func() {
+			fmt.Println("Usage: bt import [-rounding nearest|expand|shrink] toggl|clockify|timewarrior <file>")
+			flags.PrintDefaults()
+		}
+		flags.Parse(args[1:])
+		if flags.NArg() != 2 {
+			flags.Usage()
+			os.Exit(2)
+		}
+		store, err := bt.connect(bt.config, bt.profile)
+		if err != nil {
+			return err
+		}
+		defer store.Close()
+		return bt.importFile(store, flags.Arg(0), flags.Arg(1), *rounding)
+	case "gaps":
+		flags := flag.NewFlagSet("gaps", flag.ExitOnError)
+		from := flags.String("from", "", "first date to show the untracked time of, e.g. 2020-10-26, today if not set")
+		to := flags.String("to", "", "last date to show the untracked time of, today if not set")
+		flags.Usage = func() {
+			fmt.Println("Usage: bt gaps [-from <date>] [-to <date>]")
+			flags.PrintDefaults()
+		}
+		flags.Parse(args[1:])
+		if flags.NArg() != 0 {
+			flags.Usage()
+			os.Exit(2)
+		}
+		now := model.SystemClock{}.Now()
+		first, err := parseDate(*from, now)
+		if err != nil {
+			return err
+		}
+		last, err := parseDate(*to, now)
+		if err != nil {
+			return err
+		}
+		store, err := bt.connect(bt.config, bt.profile)
+		if err != nil {
+			return err
+		}
+		defer store.Close()
+		return bt.gapsReport(store, first, last, now, os.Stdout)
+	case "goals":
+		flags := flag.NewFlagSet("goals", flag.ExitOnError)
+		days := flags.Int("days", 90, "number of days up to today to find streaks in")
+		flags.Usage = func() {
+			fmt.Println("Usage: bt goals [-days <number>]")
+			flags.PrintDefaults()
+		}
+		flags.Parse(args[1:])
+		if flags.NArg() != 0 {
+			flags.Usage()
+			os.Exit(2)
+		}
+		store, err := bt.connect(bt.config, bt.profile)
+		if err != nil {
+			return err
+		}
+		defer store.Close()
+		return bt.goalsReport(store, model.SystemClock{}.Now(), *days, os.Stdout)
+	}
+	return fmt.Errorf("unknown command: %s", args[0])
+}
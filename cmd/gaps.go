@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/seven-serverless-projects/bt/model"
+	"github.com/seven-serverless-projects/bt/storage"
+)
+
+// Write the runs of untracked time in each day from the first date to the last to out,
+// a line per day, up to the current time today
+func (bt *BT) gapsReport(store storage.Store, first time.Time, last time.Time, now time.Time, out io.Writer) error {
+	if last.Format(model.DateFormat) < first.Format(model.DateFormat) {
+		return fmt.Errorf("the last date %s is before the first %s", last.Format(model.DateFormat), first.Format(model.DateFormat))
+	}
+	days, err := store.LoadDays(first, last)
+	if err != nil {
+		return err
+	}
+	for _, day := range days {
+		span := model.TrackableSpan(day, now)
+		if span.Slices() == 0 {
+			continue
+		}
+		gaps := day.Gaps(span)
+		if len(gaps) == 0 {
+			fmt.Fprintf(out, "%s: no untracked time\n", day.Date)
+			continue
+		}
+		untracked := 0
+		texts := []string{}
+		for _, gap := range gaps {
+			texts = append(texts, fmt.Sprintf("%s (%s)", gap, model.SlicesText(gap.Slices())))
+			untracked += gap.Slices()
+		}
+		fmt.Fprintf(out, "%s: %s untracked, %s\n", day.Date, model.SlicesText(untracked), strings.Join(texts, ", "))
+	}
+	return nil
+}
+
+// Return the date in the local time zone, today if it's blank
+func parseDate(date string, now time.Time) (time.Time, error) {
+	if date == "" {
+		return now, nil
+	}
+	dateTime, err := time.ParseInLocation(model.DateFormat, date, time.Local)
+	if err != nil {
+		return dateTime, fmt.Errorf("%q is not a date, e.g. 2020-10-26", date)
+	}
+	return dateTime, nil
+}
@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/seven-serverless-projects/bt/storage"
+)
+
+// TestGapsReport - test reporting the untracked time of a range of days, up to now
+func TestGapsReport(t *testing.T) {
+	t.Log("Test: gaps report...")
+	now := time.Date(2020, 10, 28, 10, 5, 0, 0, time.Local)
+	store := storage.NewMemoryStore()
+	monday, _ := store.LoadDay(now.AddDate(0, 0, -2))
+	tuesday, _ := store.LoadDay(now.AddDate(0, 0, -1))
+	for slice := 0; slice < 96; slice++ {
+		tuesday.TimeSlices[slice].ActivityID = "1"
+		if slice < 28 || slice >= 30 {
+			monday.TimeSlices[slice].ActivityID = "1"
+		}
+	}
+	store.SaveDay(monday)
+	store.SaveDay(tuesday)
+	bt := BT{}
+
+	var out bytes.Buffer
+	first := now.AddDate(0, 0, -2)
+	if err := bt.gapsReport(store, first, now.AddDate(0, 0, 1), now, &out); err != nil {
+		t.Errorf("Test: gaps report FAIL - %v", err)
+	}
+	expected := "2020-10-26: 30m untracked, 7:00 - 7:30 (30m)\n" +
+		"2020-10-27: no untracked time\n" +
+		"2020-10-28: 10h 15m untracked, 0:00 - 10:15 (10h 15m)\n"
+	if out.String() != expected {
+		t.Errorf("Test: gaps report FAIL - report %q", out.String())
+	}
+	if err := bt.gapsReport(store, now, first, now, &out); err == nil {
+		t.Errorf("Test: gaps report FAIL - last date before the first")
+	}
+	if date, err := parseDate("2020-10-26", now); err != nil || date.Format("2006-01-02") != "2020-10-26" {
+		t.Errorf("Test: gaps report FAIL - date %v %v", date, err)
+	}
+	if _, err := parseDate("10/26/2020", now); err == nil {
+		t.Errorf("Test: gaps report FAIL - not a date")
+	}
+}
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/seven-serverless-projects/bt/model"
+	"github.com/seven-serverless-projects/bt/storage"
+)
+
+// Write a report of each active activity's goals to out, with the current and longest streaks
+// of days, or weeks, they were kept, over about the specified number of days up to today.
+// The days start on a Monday, so the first week is whole.
+func (bt *BT) goalsReport(store storage.Store, today time.Time, days int, out io.Writer) error {
+	if days < 1 {
+		return fmt.Errorf("the report must cover at least 1 day, not %d", days)
+	}
+	first := model.WeekStart(today.AddDate(0, 0, 1-days))
+	storedDays, err := store.LoadDays(first, today)
+	if err != nil {
+		return err
+	}
+
+	reported := false
+	for _, activity := range bt.config.ActiveActivities() {
+		for _, goal := range activity.Goals() {
+			current, longest := model.Streaks(storedDays, activity.ID, goal)
+			period := "day"
+			if goal.Kind == model.WeeklyGoal {
+				period = "week"
+			}
+			fmt.Fprintf(out, "%s, %s: %s in a row, longest %s\n", activity.Name, goal,
+				countText(current, period), countText(longest, period))
+			reported = true
+		}
+	}
+	if !reported {
+		fmt.Fprintln(out, "None of the activities have goals, add a daily_goal, weekly_goal or limit to them in the config file")
+	}
+	return nil
+}
+
+// Return the count of the thing as text, e.g. 1 day or 3 days
+func countText(count int, thing string) string {
+	if count == 1 {
+		return fmt.Sprintf("%d %s", count, thing)
+	}
+	return fmt.Sprintf("%d %ss", count, thing)
+}
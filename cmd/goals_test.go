@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/seven-serverless-projects/bt/model"
+	"github.com/seven-serverless-projects/bt/storage"
+)
+
+// TestGoalsReport - test reporting the streaks of each activity's goals
+func TestGoalsReport(t *testing.T) {
+	t.Log("Test: goals report...")
+	today := time.Date(2020, 10, 28, 20, 0, 0, 0, time.Local) // a Wednesday
+	store := storage.NewMemoryStore()
+	for daysAgo, hours := range []int{7, 8, 5, 7, 7, 7, 7, 7, 7} {
+		day, _ := store.LoadDay(today.AddDate(0, 0, -daysAgo))
+		for slice := 0; slice < hours*4; slice++ {
+			day.TimeSlices[slice].ActivityID = "1"
+		}
+		store.SaveDay(day)
+	}
+	sleeping := model.Activity{ID: "1", Name: "Sleeping", Color: "08b4ff", Active: true, DailyGoal: "7h", WeeklyGoal: "40h"}
+	reading := model.Activity{ID: "2", Name: "Reading", Color: "abfff7", Active: false, DailyGoal: "1h"}
+	bt := BT{config: model.Config{Profile: model.Profile{Activities: []model.Activity{sleeping, reading}}}}
+
+	var out bytes.Buffer
+	if err := bt.goalsReport(store, today, 30, &out); err != nil {
+		t.Errorf("Test: goals report FAIL - %v", err)
+	}
+	expected := "Sleeping, at least 7h a day: 2 days in a row, longest 6 days\n" +
+		"Sleeping, at least 40h a week: 1 week in a row, longest 1 week\n"
+	if out.String() != expected {
+		t.Errorf("Test: goals report FAIL - report %q", out.String())
+	}
+
+	bt.config.Activities = []model.Activity{reading}
+	out.Reset()
+	if err := bt.goalsReport(store, today, 30, &out); err != nil || !bytes.Contains(out.Bytes(), []byte("None of the activities have goals")) {
+		t.Errorf("Test: goals report FAIL - no goals %q %v", out.String(), err)
+	}
+	if err := bt.goalsReport(store, today, 0, &out); err == nil {
+		t.Errorf("Test: goals report FAIL - no days reported")
+	}
+}
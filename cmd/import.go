@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/seven-serverless-projects/bt/model"
+	"github.com/seven-serverless-projects/bt/parse"
+	"github.com/seven-serverless-projects/bt/storage"
+)
+
+// Import the time entries from the specified export file of another time tracker into the store,
+// format is one of toggl, clockify or timewarrior
+func (bt *BT) importFile(store storage.Store, format string, fileName string, rounding string) error {
+	file, err := os.Open(fileName)
+	if err != nil {
+		return fmt.Errorf("unable to open the import file at %s: %w", fileName, err)
+	}
+	defer file.Close()
+
+	entries, err := parse.ParseExport(format, file)
+	if err != nil {
+		return fmt.Errorf("unable to parse the import file at %s: %w", fileName, err)
+	}
+
+	days, err := parse.ImportTimeSlices(entries, rounding, bt.importActivityID)
+	if err != nil {
+		return fmt.Errorf("unable to import the entries from %s: %w", fileName, err)
+	}
+
+	// Load each day that has imported time, assign the imported slices, and persist it
+	dates := []string{}
+	for date := range days {
+		dates = append(dates, date)
+	}
+	sort.Strings(dates)
+	for _, date := range dates {
+		forDay, _ := time.ParseInLocation(model.DateFormat, date, time.Local)
+		day, err := store.LoadDay(forDay)
+		if err != nil {
+			return err
+		}
+		if !day.Stored {
+			// The rules' time is saved with the first save of a day, as it is in the UI
+			if _, err := bt.config.ApplyRules(&day); err != nil {
+				return err
+			}
+		}
+		for slice, activityID := range days[date] {
+			day.TimeSlices[slice].ActivityID = activityID
+			day.TimeSlices[slice].Auto = false // imported time is the user's own, not a rule's
+		}
+		if err := store.SaveDay(day); err != nil {
+			return err
+		}
+		fmt.Printf("Imported %d time slices for %s\n", len(days[date]), date)
+	}
+	return nil
+}
+
+// Return the ID of the configured activity with the specified name, creating
+// and saving a new activity if there isn't one
+func (bt *BT) importActivityID(name string) (string, error) {
+	for _, activity := range bt.config.Activities {
+		if strings.EqualFold(strings.TrimSpace(activity.Name), strings.TrimSpace(name)) {
+			return activity.ID, nil
+		}
+	}
+	activity, err := model.NewActivity(name, model.NextActivityColor(bt.config.Activities))
+	if err != nil {
+		return activity.ID, err
+	}
+	bt.config.Activities = append(bt.config.Activities, activity)
+	if bt.configFile != "" {
+		if err := storage.SaveActivities(bt.configFile, bt.profile, bt.config); err != nil {
+			return activity.ID, err
+		}
+	}
+	return activity.ID, nil
+}
@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/seven-serverless-projects/bt/model"
+	"github.com/seven-serverless-projects/bt/storage"
+)
+
+// TestImportActivityID - test finding, or creating and saving, the activities of imported entries
+func TestImportActivityID(t *testing.T) {
+	t.Log("Test: import activities...")
+	writing := model.Activity{ID: "writing-id", Name: "Writing", Color: "ff7bee", Active: true}
+	bt := BT{config: model.Config{Profile: model.Profile{Activities: []model.Activity{writing}}}}
+
+	if id, err := bt.importActivityID(" writing "); err != nil || id != writing.ID || len(bt.config.Activities) != 1 {
+		t.Errorf("Test: import FAIL - matching activity %s %v", id, err)
+	}
+	bt.configFile = filepath.Join(t.TempDir(), "config.json")
+	id, err := bt.importActivityID("Gaming")
+	if err != nil || len(bt.config.Activities) != 2 || bt.config.Activities[1].Name != "Gaming" || id == "" {
+		t.Errorf("Test: import FAIL - missing activity was not created %v", err)
+	}
+	if saved, err := storage.ReadConfig(bt.configFile); err != nil || len(saved.Activities) != 2 {
+		t.Errorf("Test: import FAIL - created activity was not saved %v", err)
+	}
+	if again, _ := bt.importActivityID("gaming"); again != id || len(bt.config.Activities) != 2 {
+		t.Errorf("Test: import FAIL - created activity was duplicated")
+	}
+}
@@ -0,0 +1,185 @@
+package cmd
+
+import (
+	"bufio"
+	_ "embed" // for the default config template
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/seven-serverless-projects/bt/model"
+	"github.com/seven-serverless-projects/bt/storage"
+)
+
+// The default config file "template", used to create a new user's config
+//
+//go:embed assets/default.cfg.json
+var defaultConfigTemplate []byte
+
+// Get the configuration data from the specified configuration file
+func getConfig(configFile string) (model.Config, error) {
+	conf := model.Config{}
+	// Check for the existence of the config file
+	if _, err := os.Stat(configFile); os.IsNotExist(err) {
+		if !interactiveTerminal() {
+			// Create a default configuration
+			if err := defaultConfigFor(configFile); err != nil {
+				return conf, err
+			}
+			fmt.Println("\nYou have a new default config file at: " + configFile)
+			fmt.Print("\nPlease edit the file to match your desired configuration.\n\n")
+			os.Exit(0)
+		}
+		// Walk the user through creating their configuration
+		if err := wizardConfigFor(configFile, os.Stdin, os.Stdout); err != nil {
+			return conf, err
+		}
+		fmt.Print("\nYour new config file is at: " + configFile + "\n\n")
+	}
+	return storage.ReadConfig(configFile)
+}
+
+// Write a default configuration file to the specified file name from the default config file "template"
+func defaultConfigFor(configFile string) error {
+	userConf, err := newConfig()
+	if err != nil {
+		return err
+	}
+	// Write the user's new config file
+	return storage.WriteConfig(userConf, configFile)
+}
+
+// Write a configuration file to the specified file name from the answers the user gives the first run wizard
+func wizardConfigFor(configFile string, in io.Reader, out io.Writer) error {
+	userConf, err := newConfig()
+	if err != nil {
+		return err
+	}
+	userConf, err = runWizard(userConf, in, out)
+	if err != nil {
+		return err
+	}
+	// Write the user's new config file
+	return storage.WriteConfig(userConf, configFile)
+}
+
+// Return a new configuration from the default config file "template", with new unique IDs
+func newConfig() (model.Config, error) {
+	// Parse the default config file JSON
+	userConf := model.Config{}
+	err := json.Unmarshal(defaultConfigTemplate, &userConf)
+	if err != nil {
+		return userConf, fmt.Errorf("unable to parse the default config file template: %w", err)
+	}
+
+	// Replace the user ID with a new UUID
+	userConf.UserID = uuid.New().String()
+
+	// Replace each activity's ID with a new UUID
+	for i, activity := range userConf.Activities {
+		activity.ID = uuid.New().String()
+		userConf.Activities[i] = activity
+	}
+	return userConf, nil
+}
+
+// Ask the user for their details, storage and initial activities, starting from the
+// specified config, and return the config with their answers
+func runWizard(conf model.Config, in io.Reader, out io.Writer) (model.Config, error) {
+	reader := bufio.NewReader(in)
+	ask := func(question string, defaultAnswer string) (string, error) {
+		if defaultAnswer != "" {
+			fmt.Fprintf(out, "%s [%s]: ", question, defaultAnswer)
+		} else {
+			fmt.Fprintf(out, "%s: ", question)
+		}
+		answer, err := reader.ReadString('\n')
+		if err != nil && (err != io.EOF || answer == "") {
+			return "", fmt.Errorf("unable to read the answer to %q: %w", question, err)
+		}
+		answer = strings.TrimSpace(answer)
+		if answer == "" {
+			return defaultAnswer, nil
+		}
+		return answer, nil
+	}
+
+	fmt.Fprint(out, "\nWelcome to BubbleTimer! Let's create your config.\n\n")
+	var err error
+	if conf.Name, err = ask("Your name", ""); err != nil {
+		return conf, err
+	}
+	if conf.Email, err = ask("Your email address", ""); err != nil {
+		return conf, err
+	}
+	// Firestore is the only storage backend, so its project is the only storage setting
+	fmt.Fprint(out, "\nYour time is stored in Google Cloud Firestore.\n")
+	for {
+		if conf.ProjectID, err = ask("The Project ID of your Firebase project", ""); err != nil {
+			return conf, err
+		}
+		if model.ValidProjectID(conf.ProjectID) {
+			break
+		}
+		fmt.Fprint(out, "That's not a Project ID, find it in the settings of your project in the Firebase console.\n")
+	}
+
+	defaultNames := []string{}
+	for _, activity := range conf.Activities {
+		defaultNames = append(defaultNames, activity.Name)
+	}
+	fmt.Fprint(out, "\nList the activities you spend your time on, separated by commas.\n")
+	names, err := ask("Activities", strings.Join(defaultNames, ", "))
+	if err != nil {
+		return conf, err
+	}
+	if names != strings.Join(defaultNames, ", ") {
+		conf.Activities = []model.Activity{}
+		for _, name := range strings.Split(names, ",") {
+			name = strings.TrimSpace(name)
+			if name == "" {
+				continue
+			}
+			activity, err := model.NewActivity(name, model.NextActivityColor(conf.Activities))
+			if err != nil {
+				return conf, err
+			}
+			conf.Activities = append(conf.Activities, activity)
+		}
+	}
+	return conf, nil
+}
+
+// Return true if bt is being run interactively, rather than from a script
+func interactiveTerminal() bool {
+	info, err := os.Stdin.Stat()
+	return err == nil && (info.Mode()&os.ModeCharDevice) != 0
+}
+
+// Print a report of the problems with the specified config file, and with the profile named
+// with -profile if there is one, returning an error if any of them are fatal
+func checkConfig(conf model.Config, configFile string, profile string) error {
+	problems := model.ValidateConfig(conf)
+	if profile != "" {
+		if _, err := model.ResolveProfileName(conf, profile); err != nil {
+			problems = append(problems, model.ConfigProblem{Path: "-profile", Message: err.Error(), Fatal: true})
+		}
+	}
+	if len(problems) == 0 {
+		fmt.Println("No problems found in: " + configFile)
+		return nil
+	}
+	fmt.Printf("Problems found in: %s\n\n", configFile)
+	for _, problem := range problems {
+		fmt.Println("  " + problem.String())
+	}
+	fmt.Println()
+	if model.FatalProblems(problems) {
+		return fmt.Errorf("the config file at %s has errors that must be fixed", configFile)
+	}
+	return nil
+}
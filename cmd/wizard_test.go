@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/seven-serverless-projects/bt/model"
+)
+
+// TestRunWizard - test creating a config from the answers to the first run wizard
+func TestRunWizard(t *testing.T) {
+	t.Log("Test: first run wizard...")
+	conf, err := newConfig()
+	if err != nil {
+		t.Fatalf("Test: wizard FAIL - default config template: %v", err)
+	}
+	defaults := len(conf.Activities)
+
+	keep, err := runWizard(conf, strings.NewReader("Albert\nalbert.camus@combat.org\nbubbletimer\n\n"), ioutil.Discard)
+	if err != nil {
+		t.Errorf("Test: wizard FAIL - %v", err)
+	} else if keep.Name != "Albert" || keep.Email != "albert.camus@combat.org" || keep.ProjectID != "bubbletimer" {
+		t.Errorf("Test: wizard FAIL - answers not in config %v", keep)
+	} else if len(keep.Activities) != defaults || model.FatalProblems(model.ValidateConfig(keep)) {
+		t.Errorf("Test: wizard FAIL - default activities not kept")
+	}
+
+	replace, err := runWizard(conf, strings.NewReader("Albert\n\nbubbletimer\nThinking, Writing,,Rebelling"), ioutil.Discard)
+	if err != nil {
+		t.Errorf("Test: wizard FAIL - %v", err)
+	} else if len(replace.Activities) != 3 || replace.Activities[2].Name != "Rebelling" {
+		t.Errorf("Test: wizard FAIL - activities not replaced %v", replace.Activities)
+	} else if problems := model.ValidateConfig(replace); len(problems) != 1 || problems[0].Path != "$.email" {
+		t.Errorf("Test: wizard FAIL - unexpected problems %v", problems)
+	}
+
+	retry, err := runWizard(conf, strings.NewReader("Albert\n\n\nmy project\nbubbletimer\n\n"), ioutil.Discard)
+	if err != nil || retry.ProjectID != "bubbletimer" {
+		t.Errorf("Test: wizard FAIL - project ID not asked again %q %v", retry.ProjectID, err)
+	}
+	if _, err = runWizard(conf, strings.NewReader("Albert\n\n\n"), ioutil.Discard); err == nil {
+		t.Errorf("Test: wizard FAIL - blank project ID accepted")
+	}
+
+	_, err = runWizard(conf, strings.NewReader("Albert\n"), ioutil.Discard)
+	if err == nil {
+		t.Errorf("Test: wizard FAIL - incomplete answers accepted")
+	}
+}
+
+// TestCheckConfig - test the config check reports a wrong default profile or -profile, rather
+// than failing to resolve the profile before checking
+func TestCheckConfig(t *testing.T) {
+	t.Log("Test: config check...")
+	conf, err := newConfig()
+	if err != nil {
+		t.Fatalf("Test: config check FAIL - default config template: %v", err)
+	}
+	conf, err = runWizard(conf, strings.NewReader("Albert\nalbert.camus@combat.org\nbubbletimer\n\n"), ioutil.Discard)
+	if err != nil {
+		t.Fatalf("Test: config check FAIL - %v", err)
+	}
+	conf.Profiles = map[string]model.Profile{"work": {ProjectID: "work-project"}}
+
+	if err := checkConfig(conf, "config.json", "work"); err != nil {
+		t.Errorf("Test: config check FAIL - valid config %v", err)
+	}
+	if err := checkConfig(conf, "config.json", "wrok"); err == nil {
+		t.Errorf("Test: config check FAIL - wrong -profile passed")
+	}
+	conf.DefaultProfile = "wrok"
+	if err := checkConfig(conf, "config.json", ""); err == nil {
+		t.Errorf("Test: config check FAIL - wrong default profile passed")
+	}
+}
@@ -1,6 +1,6 @@
 module github.com/seven-serverless-projects/bt
 
-go 1.15
+go 1.16
 
 require (
 	cloud.google.com/go/firestore v1.3.0
@@ -9,7 +9,7 @@ require (
 	github.com/gdamore/tcell/v2 v2.0.1-0.20201017141208-acf90d56d591
 	github.com/google/uuid v1.1.2
 	github.com/rivo/tview v0.0.0-20201018122409-d551c850a743
-	google.golang.org/api v0.35.0 // indirect
+	google.golang.org/api v0.35.0
 	google.golang.org/appengine v1.6.7 // indirect
 	google.golang.org/grpc v1.32.0
 )
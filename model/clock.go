@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+// Clock - where the current time comes from, so it can be set to any time, e.g. in tests
+type Clock interface {
+	Now() time.Time
+}
+
+// SystemClock - the clock of the system bt is running on
+type SystemClock struct{}
+
+// Now - return the current local time
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
@@ -0,0 +1,400 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Config - user configuration data from local JSON file. The profile at the top level
+// is used, unless one of the named profiles is selected.
+type Config struct {
+	Profile
+	DefaultProfile string             `json:"default_profile,omitempty"`
+	Profiles       map[string]Profile `json:"profiles,omitempty"`
+}
+
+// Profile - the user, storage and activities for tracking one kind of time, e.g. work or
+// personal. Fields left out of a named profile are inherited from the top level profile.
+type Profile struct {
+	UserID     string                     `json:"user_id,omitempty"`
+	Name       string                     `json:"name,omitempty"`
+	Email      string                     `json:"email,omitempty"`
+	ProjectID  string                     `json:"project_id,omitempty"`
+	Activities []Activity                 `json:"activities,omitempty"`
+	Templates  map[string][]TemplateEntry `json:"templates,omitempty"` // days that look alike, by name
+	Rules      []Rule                     `json:"rules,omitempty"`     // time assigned to new days automatically
+}
+
+// Activity - label for the activity a time slice was spent doing
+type Activity struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	Color      string `json:"color"`
+	Active     bool   `json:"active"`
+	DailyGoal  string `json:"daily_goal,omitempty"`  // optional, at least this long each day, e.g. 7h
+	WeeklyGoal string `json:"weekly_goal,omitempty"` // optional, at least this long each week, e.g. 20h
+	Limit      string `json:"limit,omitempty"`       // optional, at most this long each day, e.g. 2h
+}
+
+// DefaultProfileName - the name the top level profile is known by
+const DefaultProfileName = "default"
+
+// Colors are 6 hex digits, with an optional leading #, e.g. 08b4ff
+var colorRegExp = regexp.MustCompile("^#?[0-9a-fA-F]{6}$")
+
+// Colors handed out, in order, to activities that are created by bt
+var activityColors = []string{"08b4ff", "abfff7", "ff7bee", "ffc885", "fffbaa", "b3ff8c", "ff9c9c", "c9b3ff"}
+
+// ValidColor - return true if the color is 6 hex digits, with an optional leading #
+func ValidColor(color string) bool {
+	return colorRegExp.MatchString(color)
+}
+
+// ValidProjectID - return true if the ID could be a Firebase project ID, it's not blank and has
+// no spaces or slashes
+func ValidProjectID(projectID string) bool {
+	return strings.TrimSpace(projectID) != "" && !strings.ContainsAny(projectID, " /")
+}
+
+// ActivityByID - given the ID of an activity, return the struct for the activity,
+// returns an empty activity if there's no match (e.g. it was deleted)
+func (profile Profile) ActivityByID(id string) Activity {
+	var matchingActivity Activity
+	for _, activity := range profile.Activities {
+		if activity.ID == id {
+			matchingActivity = activity
+			break
+		}
+	}
+	return matchingActivity
+}
+
+// ActiveActivities - return an array of only the activities of the profile that are active
+func (profile Profile) ActiveActivities() []Activity {
+	activeActivities := []Activity{}
+	for _, activity := range profile.Activities {
+		if activity.Active {
+			activeActivities = append(activeActivities, activity)
+		}
+	}
+	return activeActivities
+}
+
+// ResolveProfileName - return the name of the profile to use from the config, given the name
+// the user asked for. A blank name selects the config's default profile, and the top level
+// profile is returned as a blank name.
+func ResolveProfileName(conf Config, name string) (string, error) {
+	if name == "" {
+		name = conf.DefaultProfile
+	}
+	if name == "" {
+		return "", nil
+	}
+	if _, ok := conf.Profiles[name]; ok {
+		return name, nil
+	}
+	if name == DefaultProfileName {
+		return "", nil
+	}
+	return "", fmt.Errorf("there's no profile named %q in the config", name)
+}
+
+// ProfileConfig - return the config with the named profile's fields in effect at the top level,
+// name is a resolved profile name, see ResolveProfileName
+func ProfileConfig(conf Config, name string) Config {
+	if name != "" {
+		conf.Profile = MergeProfile(conf.Profile, conf.Profiles[name])
+	}
+	// Don't share activities with the config, so changes to them don't leak into it
+	conf.Activities = append([]Activity{}, conf.Activities...)
+	return conf
+}
+
+// MergeProfile - return the base profile with any fields that are set in the profile replacing its own
+func MergeProfile(base Profile, profile Profile) Profile {
+	if profile.UserID != "" {
+		base.UserID = profile.UserID
+	}
+	if profile.Name != "" {
+		base.Name = profile.Name
+	}
+	if profile.Email != "" {
+		base.Email = profile.Email
+	}
+	if profile.ProjectID != "" {
+		base.ProjectID = profile.ProjectID
+	}
+	if profile.Activities != nil {
+		base.Activities = profile.Activities
+	}
+	if profile.Templates != nil {
+		base.Templates = profile.Templates
+	}
+	if profile.Rules != nil {
+		base.Rules = profile.Rules
+	}
+	return base
+}
+
+// ProfileDisplayName - return the name of the profile for display to the user
+func ProfileDisplayName(name string) string {
+	if name == "" {
+		return DefaultProfileName
+	}
+	return name
+}
+
+// ProfileNames - return the names of the config's profiles, starting with the default profile
+func ProfileNames(conf Config) []string {
+	names := []string{DefaultProfileName}
+	for name := range conf.Profiles {
+		if name != DefaultProfileName {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names[1:])
+	return names
+}
+
+// DeletedActivities - return the activities that are in the previous activities but not the current ones
+func DeletedActivities(previous []Activity, current []Activity) []Activity {
+	deleted := []Activity{}
+	for _, activity := range previous {
+		found := false
+		for _, currentActivity := range current {
+			if currentActivity.ID == activity.ID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			deleted = append(deleted, activity)
+		}
+	}
+	return deleted
+}
+
+// NextActivityColor - return the color for the next activity to add to the specified activities
+func NextActivityColor(activities []Activity) string {
+	return activityColors[len(activities)%len(activityColors)]
+}
+
+// NewActivity - return a new active activity with the specified name and color, with a new unique ID
+func NewActivity(name string, color string) (Activity, error) {
+	activity := Activity{
+		ID:     uuid.New().String(),
+		Name:   strings.TrimSpace(name),
+		Color:  strings.TrimPrefix(color, "#"),
+		Active: true,
+	}
+	return activity, validateActivity(activity)
+}
+
+// UpdateActivity - apply the update to the activity with the specified ID in the activities.
+// The activities are left unchanged if the update makes the activity invalid.
+func UpdateActivity(activities []Activity, id string, update func(activity *Activity)) (Activity, error) {
+	for i := range activities {
+		if activities[i].ID == id {
+			activity := activities[i]
+			update(&activity)
+			activity.Name = strings.TrimSpace(activity.Name)
+			activity.Color = strings.TrimPrefix(activity.Color, "#")
+			if err := validateActivity(activity); err != nil {
+				return activity, err
+			}
+			activities[i] = activity
+			return activity, nil
+		}
+	}
+	return Activity{}, fmt.Errorf("no activity with the ID %s", id)
+}
+
+// Return an error if the activity can't be saved
+func validateActivity(activity Activity) error {
+	if activity.Name == "" {
+		return errors.New("the activity name can't be blank")
+	}
+	if !ValidColor(activity.Color) {
+		return fmt.Errorf("%q is not a 6 digit hex color, e.g. 08b4ff", activity.Color)
+	}
+	return nil
+}
+
+// ConfigProblem - an issue with the configuration, located by its JSON path
+type ConfigProblem struct {
+	Path    string
+	Message string
+	Fatal   bool // bt can't run correctly until it's fixed
+}
+
+func (problem ConfigProblem) String() string {
+	severity := "warning"
+	if problem.Fatal {
+		severity = "error"
+	}
+	return fmt.Sprintf("%s: %s - %s", severity, problem.Path, problem.Message)
+}
+
+// ValidateConfig - return every problem found with the configuration and its profiles,
+// an empty array if there are none
+func ValidateConfig(conf Config) []ConfigProblem {
+	topLevelPath := func(field string) string {
+		return "$." + field
+	}
+	// When another profile is the default, the top level profile is just a base for the others
+	_, hasDefault := conf.Profiles[conf.DefaultProfile]
+	problems := validateProfile(conf.Profile, topLevelPath, !hasDefault)
+	if conf.DefaultProfile != "" && !hasDefault && conf.DefaultProfile != DefaultProfileName {
+		problems = append(problems, ConfigProblem{"$.default_profile", fmt.Sprintf("there's no profile named %q", conf.DefaultProfile), true})
+	}
+
+	names := []string{}
+	for name := range conf.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	reported := make(map[ConfigProblem]bool)
+	for _, problem := range problems {
+		reported[problem] = true
+	}
+	for _, name := range names {
+		profile := conf.Profiles[name]
+		// Problems with fields inherited from the top level are reported at the top level
+		profilePath := func(field string) string {
+			set := map[string]bool{
+				"user_id":    profile.UserID != "",
+				"name":       profile.Name != "",
+				"email":      profile.Email != "",
+				"project_id": profile.ProjectID != "",
+				"activities": profile.Activities != nil,
+				"templates":  profile.Templates != nil,
+				"rules":      profile.Rules != nil,
+			}
+			if set[field] {
+				return fmt.Sprintf("$.profiles.%s.%s", name, field)
+			}
+			return "$." + field
+		}
+		for _, problem := range validateProfile(MergeProfile(conf.Profile, profile), profilePath, true) {
+			if !reported[problem] {
+				reported[problem] = true
+				problems = append(problems, problem)
+			}
+		}
+	}
+	return problems
+}
+
+// Return every problem found with the profile, using the path function to locate each field
+// of the profile in the config. Unless required is set, blank fields aren't a problem.
+func validateProfile(profile Profile, path func(field string) string, required bool) []ConfigProblem {
+	problems := []ConfigProblem{}
+	problem := func(path string, fatal bool, format string, args ...interface{}) {
+		problems = append(problems, ConfigProblem{path, fmt.Sprintf(format, args...), fatal})
+	}
+
+	if profile.UserID == "" {
+		if required {
+			problem(path("user_id"), true, "is required")
+		}
+	} else if _, err := uuid.Parse(profile.UserID); err != nil {
+		problem(path("user_id"), true, "%q is not a UUID", profile.UserID)
+	}
+	if required && strings.TrimSpace(profile.Name) == "" {
+		problem(path("name"), false, "is blank")
+	}
+	if required && strings.TrimSpace(profile.Email) == "" {
+		problem(path("email"), false, "is blank")
+	}
+	if strings.TrimSpace(profile.ProjectID) == "" {
+		if required {
+			problem(path("project_id"), true, "is required")
+		}
+	} else if !ValidProjectID(profile.ProjectID) {
+		problem(path("project_id"), true, "%q is not a Firebase project ID", profile.ProjectID)
+	}
+
+	if required && len(profile.Activities) == 0 {
+		problem(path("activities"), true, "at least one activity is required")
+	}
+	activeCount := 0
+	activityIDs := make(map[string]int)
+	for i, activity := range profile.Activities {
+		activityPath := fmt.Sprintf("%s[%d]", path("activities"), i)
+		if activity.ID == "" {
+			problem(activityPath+".id", true, "is required")
+		} else if first, duplicate := activityIDs[activity.ID]; duplicate {
+			problem(activityPath+".id", true, "%q duplicates the ID of %s[%d]", activity.ID, path("activities"), first)
+		} else {
+			activityIDs[activity.ID] = i
+		}
+		if strings.TrimSpace(activity.Name) == "" {
+			problem(activityPath+".name", false, "is blank")
+		}
+		if !ValidColor(activity.Color) {
+			problem(activityPath+".color", false, "%q is not a 6 digit hex color, e.g. 08b4ff", activity.Color)
+		}
+		for _, goal := range [][2]string{{"daily_goal", activity.DailyGoal}, {"weekly_goal", activity.WeeklyGoal}, {"limit", activity.Limit}} {
+			if _, err := ParseGoal(goal[1]); goal[1] != "" && err != nil {
+				problem(activityPath+"."+goal[0], false, "%s", err.Error())
+			}
+		}
+		if activity.Active {
+			activeCount++
+		}
+	}
+	if len(profile.Activities) > 0 && activeCount == 0 {
+		problem(path("activities"), false, "no activities are active")
+	}
+	for _, name := range profile.TemplateNames() {
+		for i, entry := range profile.Templates[name] {
+			entryPath := fmt.Sprintf("%s.%s[%d]", path("templates"), name, i)
+			if _, err := ParseSpan(entry.Time); err != nil {
+				problem(entryPath+".time", false, "%s", err.Error())
+			}
+			if _, ok := profile.ActivityByName(entry.Activity); !ok {
+				problem(entryPath+".activity", false, "there's no active activity named %q", entry.Activity)
+			}
+		}
+	}
+	for i, rule := range profile.Rules {
+		rulePath := fmt.Sprintf("%s[%d]", path("rules"), i)
+		if _, err := parseWeekdays(rule.Days); err != nil {
+			problem(rulePath+".days", false, "%s", err.Error())
+		}
+		if span, err := parseClockSpan(rule.Time); err != nil || span.Start == span.End {
+			problem(rulePath+".time", false, "%q is not a span of the day, e.g. 23:00-7:00", rule.Time)
+		}
+		if _, ok := profile.ActivityByName(rule.Activity); !ok {
+			problem(rulePath+".activity", false, "there's no active activity named %q", rule.Activity)
+		}
+	}
+	return problems
+}
+
+// FatalProblems - return true if any of the problems prevent bt from running
+func FatalProblems(problems []ConfigProblem) bool {
+	for _, problem := range problems {
+		if problem.Fatal {
+			return true
+		}
+	}
+	return false
+}
+
+// ProfileProblem - return an error for the first of the profile's problems that prevents bt
+// from running with it, nil if there isn't one. The config is a resolved profile config,
+// see ProfileConfig.
+func ProfileProblem(conf Config, name string) error {
+	for _, problem := range ValidateConfig(Config{Profile: conf.Profile}) {
+		if problem.Fatal {
+			return fmt.Errorf("the %s profile has errors: %s", ProfileDisplayName(name), problem.String())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,187 @@
+package model
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// TestValidateConfig - test that config problems are reported with their JSON path
+func TestValidateConfig(t *testing.T) {
+
+	type testCase struct {
+		config Config
+		paths  []string
+		fatal  bool
+	}
+
+	userID := "4fb61541-4219-41cb-a3c3-3cd525f4d7ab"
+	sleeping := Activity{ID: "25b69838-1899-11eb-93a1-003ee1cbbd65", Name: "Sleeping", Color: "08b4ff", Active: true}
+	writing := Activity{ID: "b8a7a6f8-ce15-42f6-aa05-988e346f7afb", Name: "Writing", Color: "#ff7bee", Active: true}
+	valid := func() Config {
+		return Config{Profile: Profile{UserID: userID, Name: "Albert", Email: "albert.camus@combat.org", ProjectID: "bubbletimer",
+			Activities: []Activity{sleeping, writing}}}
+	}
+
+	missingProject := valid()
+	missingProject.ProjectID = ""
+	placeholderProject := valid()
+	placeholderProject.ProjectID = "Replace with the Project ID for your Firebase project"
+	badUser := valid()
+	badUser.UserID = "TBD"
+	duplicateID := valid()
+	duplicateID.Activities = []Activity{sleeping, writing, sleeping}
+	badColor := valid()
+	badColor.Activities = []Activity{sleeping, {ID: writing.ID, Name: "Writing", Color: "pink", Active: true}}
+	blankNames := valid()
+	blankNames.Name = ""
+	blankNames.Activities = []Activity{sleeping, {ID: writing.ID, Name: " ", Color: "ff7bee", Active: true}}
+	noActivities := valid()
+	noActivities.Activities = []Activity{}
+	noActive := valid()
+	noActive.Activities = []Activity{{ID: sleeping.ID, Name: "Sleeping", Color: "08b4ff", Active: false}}
+	badGoals := valid()
+	badGoals.Activities = []Activity{sleeping, {ID: writing.ID, Name: "Writing", Color: "ff7bee", Active: true,
+		DailyGoal: "7h", WeeklyGoal: "lots", Limit: "-2h"}}
+	badTemplates := valid()
+	badTemplates.Templates = map[string][]TemplateEntry{"workday": {{"0:00-7:00", "sleeping"}, {"17-9", "Writing"}, {"9-17", "Day Job"}}}
+	badRules := valid()
+	badRules.Rules = []Rule{{"weekdays", "23:00-7:00", "Sleeping"}, {"mon,thurs", "9-9", "Writing"}, {"daily", "12-13", "Lunch"}}
+
+	testCases := []testCase{
+		{valid(), []string{}, false},
+		{missingProject, []string{"$.project_id"}, true},
+		{placeholderProject, []string{"$.project_id"}, true},
+		{badUser, []string{"$.user_id"}, true},
+		{duplicateID, []string{"$.activities[2].id"}, true},
+		{badColor, []string{"$.activities[1].color"}, false},
+		{blankNames, []string{"$.name", "$.activities[1].name"}, false},
+		{noActivities, []string{"$.activities"}, true},
+		{noActive, []string{"$.activities"}, false},
+		{badGoals, []string{"$.activities[1].weekly_goal", "$.activities[1].limit"}, false},
+		{badTemplates, []string{"$.templates.workday[1].time", "$.templates.workday[2].activity"}, false},
+		{badRules, []string{"$.rules[1].days", "$.rules[1].time", "$.rules[2].activity"}, false}}
+	t.Log("Test: validating configs...")
+	for i, testCase := range testCases {
+		problems := ValidateConfig(testCase.config)
+		paths := []string{}
+		for _, problem := range problems {
+			paths = append(paths, problem.Path)
+		}
+		if fmt.Sprint(paths) != fmt.Sprint(testCase.paths) {
+			t.Errorf("Test: validate config FAIL - problem paths %v in test case %d", paths, i+1)
+		} else if FatalProblems(problems) != testCase.fatal {
+			t.Errorf("Test: validate config FAIL - fatal outcome in test case %d", i+1)
+		} else {
+			t.Log("Test: success for config test case " + fmt.Sprint(i+1))
+		}
+	}
+}
+
+// TestActivityUpdates - test creating and updating activities
+func TestActivityUpdates(t *testing.T) {
+	t.Log("Test: activity updates...")
+	activities := []Activity{{ID: "1", Name: "Sleeping", Color: "08b4ff", Active: true}}
+
+	if _, err := NewActivity(" ", "33cc66"); err == nil {
+		t.Errorf("Test: activity updates FAIL - blank name created")
+	}
+	if _, err := NewActivity("Exercise", "green"); err == nil {
+		t.Errorf("Test: activity updates FAIL - invalid color created")
+	}
+	exercise, err := NewActivity("Exercise", "#33cc66")
+	if err != nil || exercise.Color != "33cc66" || exercise.ID == "" || !exercise.Active {
+		t.Errorf("Test: activity updates FAIL - new %v %v", exercise, err)
+	}
+	activities = append(activities, exercise)
+	if _, err := UpdateActivity(activities, exercise.ID, func(activity *Activity) { activity.Color = "nope" }); err == nil {
+		t.Errorf("Test: activity updates FAIL - invalid color update made")
+	}
+	_, err = UpdateActivity(activities, exercise.ID, func(activity *Activity) {
+		activity.Name = " Running "
+		activity.Active = false
+	})
+	if err != nil || activities[1] != (Activity{ID: exercise.ID, Name: "Running", Color: "33cc66", Active: false}) {
+		t.Errorf("Test: activity updates FAIL - update %v %v", activities, err)
+	}
+	if _, err := UpdateActivity(activities, "missing", func(activity *Activity) {}); err == nil {
+		t.Errorf("Test: activity updates FAIL - missing activity updated")
+	}
+	if active := (Profile{Activities: activities}).ActiveActivities(); len(active) != 1 || active[0].Name != "Sleeping" {
+		t.Errorf("Test: activity updates FAIL - active activities %v", active)
+	}
+}
+
+// TestProfiles - test selecting and validating named profiles
+func TestProfiles(t *testing.T) {
+	t.Log("Test: profiles...")
+	sleeping := Activity{ID: "25b69838-1899-11eb-93a1-003ee1cbbd65", Name: "Sleeping", Color: "08b4ff", Active: true}
+	meetings := Activity{ID: "135c5eba-a174-46b3-ba0e-8bbcf0035897", Name: "Meetings", Color: "fffbaa", Active: true}
+	conf := Config{
+		Profile: Profile{UserID: "4fb61541-4219-41cb-a3c3-3cd525f4d7ab", Name: "Albert", Email: "albert.camus@combat.org",
+			ProjectID: "personal-project", Activities: []Activity{sleeping}},
+		Profiles: map[string]Profile{
+			"work": {UserID: "f54a3fcc-5bcb-44f5-afd9-87b9666c99f9", ProjectID: "work-project", Activities: []Activity{meetings}},
+		},
+	}
+
+	if name, err := ResolveProfileName(conf, ""); name != "" || err != nil {
+		t.Errorf("Test: profiles FAIL - default profile %q %v", name, err)
+	}
+	if name, err := ResolveProfileName(conf, DefaultProfileName); name != "" || err != nil {
+		t.Errorf("Test: profiles FAIL - named default profile %q %v", name, err)
+	}
+	if _, err := ResolveProfileName(conf, "play"); err == nil {
+		t.Errorf("Test: profiles FAIL - missing profile resolved")
+	}
+	conf.DefaultProfile = "work"
+	if name, err := ResolveProfileName(conf, ""); name != "work" || err != nil {
+		t.Errorf("Test: profiles FAIL - config default profile %q %v", name, err)
+	}
+
+	work := ProfileConfig(conf, "work")
+	if work.UserID != conf.Profiles["work"].UserID || work.ProjectID != "work-project" ||
+		work.Name != "Albert" || len(work.Activities) != 1 || work.Activities[0] != meetings {
+		t.Errorf("Test: profiles FAIL - work profile config %v", work.Profile)
+	}
+	if problems := ValidateConfig(conf); len(problems) != 0 {
+		t.Errorf("Test: profiles FAIL - unexpected problems %v", problems)
+	}
+	if err := ProfileProblem(work, "work"); err != nil {
+		t.Errorf("Test: profiles FAIL - work profile problem %v", err)
+	}
+	if names := ProfileNames(conf); fmt.Sprint(names) != "[default work]" {
+		t.Errorf("Test: profiles FAIL - profile names %v", names)
+	}
+
+	broken := conf
+	broken.DefaultProfile = "play"
+	broken.Profiles = map[string]Profile{"work": {UserID: "TBD", Activities: []Activity{meetings, meetings}}}
+	paths := []string{}
+	for _, problem := range ValidateConfig(broken) {
+		paths = append(paths, problem.Path)
+	}
+	expected := []string{"$.default_profile", "$.profiles.work.user_id", "$.profiles.work.activities[1].id"}
+	if fmt.Sprint(paths) != fmt.Sprint(expected) {
+		t.Errorf("Test: profiles FAIL - problem paths %v", paths)
+	}
+	if err := ProfileProblem(ProfileConfig(broken, "work"), "work"); err == nil {
+		t.Errorf("Test: profiles FAIL - broken profile has no problem")
+	}
+}
+
+// TestDeletedActivities - test finding the activities removed by a config edit
+func TestDeletedActivities(t *testing.T) {
+	t.Log("Test: deleted activities...")
+	sleeping := Activity{ID: "1", Name: "Sleeping", Color: "08b4ff", Active: true}
+	gaming := Activity{ID: "2", Name: "Gaming", Color: "ffc885", Active: true}
+	hiddenGaming := Activity{ID: "2", Name: "Gaming", Color: "ffc885", Active: false}
+	writing := Activity{ID: "3", Name: "Writing", Color: "ff7bee", Active: true}
+
+	if deleted := DeletedActivities([]Activity{sleeping, gaming}, []Activity{hiddenGaming, sleeping, writing}); len(deleted) != 0 {
+		t.Errorf("Test: deleted activities FAIL - %v", deleted)
+	}
+	if deleted := DeletedActivities([]Activity{sleeping, gaming, writing}, []Activity{writing}); !reflect.DeepEqual(deleted, []Activity{sleeping, gaming}) {
+		t.Errorf("Test: deleted activities FAIL - %v", deleted)
+	}
+}
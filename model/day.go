@@ -0,0 +1,55 @@
+// Package model has BubbleTimer's data, the days of time slices and the activities they're
+// spent doing, and the rules for them. It has no dependencies on storage or the UI.
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// DateFormat - the ISO 8601 format of the dates of days
+	DateFormat = "2006-01-02"
+	// SlicesPerDay - the number of time slices in a day
+	SlicesPerDay = 96
+	// SliceDuration - the length of time of each time slice
+	SliceDuration = 15 * time.Minute
+)
+
+// Day - a single day of 96 (15m) time slices
+type Day struct {
+	Date       string                  // ISO 8601
+	TimeSlices [SlicesPerDay]TimeSlice // 0 to 95 for each 15m of a day, 0 = 0:00-0:15, 4 = 1:00-1:15, 95 = 23:45-24:00
+	Stored     bool                    // loaded from storage, false for a day that's never been saved
+}
+
+// TimeSlice - one unit of time, either uncategorized, or associated with at activity
+type TimeSlice struct {
+	Slice      int
+	ActivityID string
+	Note       string // optional, the user's description of the time slice
+	Auto       bool   // assigned by a rule rather than the user, see Profile.ApplyRules
+}
+
+// NewDay - return a day with no time assigned, for the date of the specified time
+func NewDay(forDay time.Time) Day {
+	day := Day{Date: forDay.Format(DateFormat)}
+	for i := range day.TimeSlices {
+		day.TimeSlices[i].Slice = i
+	}
+	return day
+}
+
+// Time - return the date of the day as a time in the local time zone
+func (day Day) Time() (time.Time, error) {
+	dayTime, err := time.ParseInLocation(DateFormat, day.Date, time.Local)
+	if err != nil {
+		return dayTime, fmt.Errorf("unable to parse the date %s: %w", day.Date, err)
+	}
+	return dayTime, nil
+}
+
+// SliceForTime - return the index in the day of the time slice containing the specified time
+func SliceForTime(t time.Time) int {
+	return (t.Hour() * 4) + (t.Minute() / 15)
+}
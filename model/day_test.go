@@ -0,0 +1,44 @@
+package model
+
+import (
+	"fmt"
+	"testing"
+	"time"
+	_ "time/tzdata" // so the DST test cases don't depend on the system's time zone database
+)
+
+// TestDayTime - test the date of a day, and the day before it, are calendar days, on the days
+// daylight saving time starts and ends, when they're 23 and 25 hours long
+func TestDayTime(t *testing.T) {
+
+	type testCase struct {
+		now   time.Time
+		date  string
+		prior string
+	}
+
+	newYork, _ := time.LoadLocation("America/New_York")
+	local := time.Local
+	time.Local = newYork
+	defer func() { time.Local = local }()
+
+	testCases := []testCase{
+		{time.Date(2021, 3, 14, 23, 30, 0, 0, newYork), "2021-03-14", "2021-03-13"},
+		{time.Date(2021, 3, 15, 0, 30, 0, 0, newYork), "2021-03-15", "2021-03-14"},
+		{time.Date(2021, 11, 7, 23, 30, 0, 0, newYork), "2021-11-07", "2021-11-06"},
+		{time.Date(2021, 11, 8, 0, 30, 0, 0, newYork), "2021-11-08", "2021-11-07"}}
+	t.Log("Test: day time...")
+	for i, testCase := range testCases {
+		day := NewDay(testCase.now)
+		dayTime, err := day.Time()
+		if err != nil || day.Date != testCase.date || dayTime.Format(DateFormat) != testCase.date {
+			t.Errorf("Test: day time FAIL - date %s in test case %d", day.Date, i+1)
+		} else if prior := NewDay(testCase.now.AddDate(0, 0, -1)); prior.Date != testCase.prior {
+			t.Errorf("Test: day time FAIL - prior date %s in test case %d", prior.Date, i+1)
+		} else if prior := NewDay(dayTime.AddDate(0, 0, -1)); prior.Date != testCase.prior {
+			t.Errorf("Test: day time FAIL - prior date from midnight %s in test case %d", prior.Date, i+1)
+		} else {
+			t.Log("Test: success for day time test case " + fmt.Sprint(i+1))
+		}
+	}
+}
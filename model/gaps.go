@@ -0,0 +1,78 @@
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+// Span - a run of consecutive time slices of a day, from the index of the first time slice
+// to the index after the last, e.g. 9:00-17:00 is {36, 68}
+type Span struct {
+	Start int
+	End   int
+}
+
+// WholeDay - the span of every time slice of a day
+var WholeDay = Span{0, SlicesPerDay}
+
+// Slices - return the number of time slices in the span
+func (span Span) Slices() int {
+	return span.End - span.Start
+}
+
+// String - return the span as wall clock times, e.g. 9:00 - 17:00
+func (span Span) String() string {
+	return ClockText(span.Start) + " - " + ClockText(span.End)
+}
+
+// ClockText - return the time of day the time slice with the index starts at, in 24h time,
+// e.g. 9:15 for 37, and 24:00 for the end of the day
+func ClockText(slice int) string {
+	return fmt.Sprintf("%d:%02d", slice/4, (slice%4)*15)
+}
+
+// TrackableSpan - return the span of the day that time can have been spent in as of now: the
+// whole of a past day, today up to the end of the time slice containing now, and none of a
+// day in the future
+func TrackableSpan(day Day, now time.Time) Span {
+	today := now.Format(DateFormat)
+	switch {
+	case day.Date < today:
+		return WholeDay
+	case day.Date == today:
+		return Span{0, SliceForTime(now) + 1}
+	}
+	return Span{0, 0}
+}
+
+// Gaps - return the runs of time slices in the span that aren't assigned to an activity
+func (day Day) Gaps(span Span) []Span {
+	gaps := []Span{}
+	for slice := span.Start; slice < span.End; slice++ {
+		if day.TimeSlices[slice].ActivityID != "" {
+			continue
+		}
+		if len(gaps) > 0 && gaps[len(gaps)-1].End == slice {
+			gaps[len(gaps)-1].End++
+		} else {
+			gaps = append(gaps, Span{slice, slice + 1})
+		}
+	}
+	return gaps
+}
+
+// Fill - assign the activity to the time slices in the span that aren't assigned to an activity,
+// or are only assigned by a rule, never changing those the user assigned, and return the number
+// of time slices assigned
+func (day *Day) Fill(span Span, activityID string) int {
+	filled := 0
+	for slice := span.Start; slice < span.End; slice++ {
+		if day.TimeSlices[slice].ActivityID != "" && !day.TimeSlices[slice].Auto {
+			continue
+		}
+		day.TimeSlices[slice].ActivityID = activityID
+		day.TimeSlices[slice].Auto = false
+		filled++
+	}
+	return filled
+}
@@ -0,0 +1,81 @@
+package model
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// TestGaps - test finding the runs of untracked time in a day, at the start and end of the
+// day and of the span looked in
+func TestGaps(t *testing.T) {
+
+	type testCase struct {
+		assigned []int // the indexes of the time slices assigned to an activity
+		span     Span
+		gaps     string
+	}
+
+	testCases := []testCase{
+		{[]int{}, WholeDay, "[0:00 - 24:00]"},
+		{[]int{0, 95}, WholeDay, "[0:15 - 23:45]"},
+		{[]int{1, 2, 40}, WholeDay, "[0:00 - 0:15 0:45 - 10:00 10:15 - 24:00]"},
+		{[]int{36, 37}, Span{36, 68}, "[9:30 - 17:00]"},
+		{[]int{36, 67}, Span{36, 68}, "[9:15 - 16:45]"},
+		{[]int{10}, Span{10, 11}, "[]"},
+		{[]int{}, Span{20, 20}, "[]"}}
+	t.Log("Test: gaps...")
+	for i, testCase := range testCases {
+		day := NewDay(time.Date(2020, 10, 26, 0, 0, 0, 0, time.UTC))
+		for _, slice := range testCase.assigned {
+			day.TimeSlices[slice].ActivityID = "1"
+		}
+		if gaps := fmt.Sprint(day.Gaps(testCase.span)); gaps != testCase.gaps {
+			t.Errorf("Test: gaps FAIL - %s in test case %d", gaps, i+1)
+		} else {
+			t.Log("Test: success for gaps test case " + fmt.Sprint(i+1))
+		}
+	}
+}
+
+// TestFill - test filling untracked time never changes time the user assigned, but does replace
+// time assigned by rules
+func TestFill(t *testing.T) {
+	t.Log("Test: fill...")
+	day := NewDay(time.Date(2020, 10, 26, 0, 0, 0, 0, time.UTC))
+	day.TimeSlices[36].ActivityID = "1"
+	day.TimeSlices[50].ActivityID = "2"
+	day.TimeSlices[51].Note = "lunch"
+	day.TimeSlices[60] = TimeSlice{Slice: 60, ActivityID: "2", Auto: true}
+
+	if filled := day.Fill(Span{36, 68}, "3"); filled != 30 {
+		t.Errorf("Test: fill FAIL - filled %d time slices", filled)
+	}
+	if day.TimeSlices[36].ActivityID != "1" || day.TimeSlices[50].ActivityID != "2" {
+		t.Errorf("Test: fill FAIL - assigned time changed")
+	}
+	if day.TimeSlices[35].ActivityID != "" || day.TimeSlices[68].ActivityID != "" {
+		t.Errorf("Test: fill FAIL - time outside the span filled")
+	}
+	if day.TimeSlices[51].ActivityID != "3" || day.TimeSlices[51].Note != "lunch" || day.TimeSlices[67].ActivityID != "3" ||
+		day.TimeSlices[60].ActivityID != "3" || day.TimeSlices[60].Auto {
+		t.Errorf("Test: fill FAIL - untracked time not filled")
+	}
+	if filled := day.Fill(Span{36, 68}, "4"); filled != 0 {
+		t.Errorf("Test: fill FAIL - filled %d time slices again", filled)
+	}
+}
+
+// TestTrackableSpan - test the span of a day time can have been spent in, as of now
+func TestTrackableSpan(t *testing.T) {
+	t.Log("Test: trackable span...")
+	now := time.Date(2020, 10, 26, 10, 5, 0, 0, time.UTC)
+	for daysAgo, expected := range map[int]Span{1: WholeDay, 0: {0, 41}, -1: {0, 0}} {
+		if span := TrackableSpan(NewDay(now.AddDate(0, 0, -daysAgo)), now); span != expected {
+			t.Errorf("Test: trackable span FAIL - %v %d days ago", span, daysAgo)
+		}
+	}
+	if span := TrackableSpan(NewDay(now), now.Add(13*time.Hour+54*time.Minute)); span != WholeDay {
+		t.Errorf("Test: trackable span FAIL - %v just before midnight", span)
+	}
+}
@@ -0,0 +1,209 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// GoalKind - what a goal asks of the time spent on an activity
+type GoalKind int
+
+const (
+	// DailyGoal - at least the time on each day
+	DailyGoal GoalKind = iota
+	// WeeklyGoal - at least the time in each week, Monday to Sunday
+	WeeklyGoal
+	// DailyLimit - at most the time on each day
+	DailyLimit
+)
+
+// Goal - a target for the time spent on an activity, in time slices
+type Goal struct {
+	Kind   GoalKind
+	Slices int
+}
+
+// GoalState - how the time spent on an activity compares to a goal
+type GoalState int
+
+const (
+	// GoalBelow - not yet near a goal, or comfortably under a limit
+	GoalBelow GoalState = iota
+	// GoalNear - approaching a goal or a limit
+	GoalNear
+	// GoalMet - reached a goal
+	GoalMet
+	// GoalExceeded - went over a limit
+	GoalExceeded
+)
+
+// The percentage of a goal or limit at which it's being approached
+const nearGoalPercent = 75
+
+// ParseGoal - return the number of time slices in a goal duration, e.g. 7h, 2h30m or 45m,
+// rounded up to a whole time slice
+func ParseGoal(goal string) (int, error) {
+	duration, err := time.ParseDuration(strings.ReplaceAll(goal, " ", ""))
+	if err != nil || duration <= 0 {
+		return 0, fmt.Errorf("%q is not a duration, e.g. 7h or 2h30m", goal)
+	}
+	if duration > 7*24*time.Hour {
+		return 0, fmt.Errorf("%q is longer than a week", goal)
+	}
+	return int((duration + SliceDuration - 1) / SliceDuration), nil
+}
+
+// Goals - return the activity's goals and limit, leaving out any that aren't valid
+func (activity Activity) Goals() []Goal {
+	goals := []Goal{}
+	for _, goal := range []struct {
+		kind     GoalKind
+		duration string
+	}{{DailyGoal, activity.DailyGoal}, {WeeklyGoal, activity.WeeklyGoal}, {DailyLimit, activity.Limit}} {
+		if goal.duration == "" {
+			continue
+		}
+		if slices, err := ParseGoal(goal.duration); err == nil {
+			goals = append(goals, Goal{goal.kind, slices})
+		}
+	}
+	return goals
+}
+
+// State - return how the number of time slices spent on the activity compares to the goal
+func (goal Goal) State(slices int) GoalState {
+	near := slices*100 >= goal.Slices*nearGoalPercent
+	if goal.Kind == DailyLimit {
+		if slices > goal.Slices {
+			return GoalExceeded
+		} else if near {
+			return GoalNear
+		}
+		return GoalBelow
+	}
+	if slices >= goal.Slices {
+		return GoalMet
+	} else if near {
+		return GoalNear
+	}
+	return GoalBelow
+}
+
+// Kept - return true if the number of time slices spent on the activity meets the goal,
+// or stays within the limit
+func (goal Goal) Kept(slices int) bool {
+	state := goal.State(slices)
+	if goal.Kind == DailyLimit {
+		return state != GoalExceeded
+	}
+	return state == GoalMet
+}
+
+// String - return the goal as the user would say it, e.g. at least 7h a day
+func (goal Goal) String() string {
+	switch goal.Kind {
+	case WeeklyGoal:
+		return "at least " + SlicesText(goal.Slices) + " a week"
+	case DailyLimit:
+		return "at most " + SlicesText(goal.Slices) + " a day"
+	}
+	return "at least " + SlicesText(goal.Slices) + " a day"
+}
+
+// SlicesText - return the time in the number of time slices as text, e.g. 2h 15m, or 0m
+func SlicesText(slices int) string {
+	hours, minutes := slices/4, (slices%4)*15
+	switch {
+	case hours > 0 && minutes > 0:
+		return fmt.Sprintf("%dh %dm", hours, minutes)
+	case hours > 0:
+		return fmt.Sprintf("%dh", hours)
+	}
+	return fmt.Sprintf("%dm", minutes)
+}
+
+// ActivitySlices - return the number of time slices of the days spent on each activity, by its ID
+func ActivitySlices(days ...Day) map[string]int {
+	counts := make(map[string]int)
+	for _, day := range days {
+		for _, timeSlice := range day.TimeSlices {
+			if timeSlice.ActivityID != "" {
+				counts[timeSlice.ActivityID]++
+			}
+		}
+	}
+	return counts
+}
+
+// Tracked - return true if any of the day's time slices are assigned to an activity
+func (day Day) Tracked() bool {
+	for _, timeSlice := range day.TimeSlices {
+		if timeSlice.ActivityID != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// WeekStart - return midnight on the Monday of the week of the specified time, in its time zone
+func WeekStart(t time.Time) time.Time {
+	daysSinceMonday := (int(t.Weekday()) + 6) % 7
+	return time.Date(t.Year(), t.Month(), t.Day()-daysSinceMonday, 0, 0, 0, 0, t.Location())
+}
+
+// Dates - return noon on each calendar day from the date of the first time to the date of
+// the last, in the first time's time zone, so adding days across a daylight saving time
+// change stays on the same date
+func Dates(first time.Time, last time.Time) []time.Time {
+	dates := []time.Time{}
+	date := time.Date(first.Year(), first.Month(), first.Day(), 12, 0, 0, 0, first.Location())
+	for lastDate := last.Format(DateFormat); date.Format(DateFormat) <= lastDate; date = date.AddDate(0, 0, 1) {
+		dates = append(dates, date)
+	}
+	return dates
+}
+
+// Streaks - return the current and longest runs of consecutive days (weeks for a weekly goal)
+// the goal was kept for the activity. The days are consecutive calendar days, oldest first,
+// ending with today. Today, or this week, extends the current streak once the goal is kept,
+// but being in progress, doesn't end it before then, unless it's over a limit. Days with no
+// time tracked don't keep any goal, even a limit.
+func Streaks(days []Day, activityID string, goal Goal) (current int, longest int) {
+	type period struct {
+		slices  int
+		tracked bool
+	}
+	periods := []period{}
+	week := ""
+	for _, day := range days {
+		if goal.Kind == WeeklyGoal {
+			dayTime, err := day.Time()
+			if err != nil {
+				continue
+			}
+			if monday := WeekStart(dayTime).Format(DateFormat); monday != week || len(periods) == 0 {
+				week = monday
+				periods = append(periods, period{})
+			}
+		} else {
+			periods = append(periods, period{})
+		}
+		last := &periods[len(periods)-1]
+		last.slices += ActivitySlices(day)[activityID]
+		last.tracked = last.tracked || day.Tracked()
+	}
+	run := 0
+	for i, p := range periods {
+		inProgress := i == len(periods)-1
+		if p.tracked && goal.Kept(p.slices) {
+			run++
+			if run > longest {
+				longest = run
+			}
+		} else if !inProgress || (p.tracked && goal.Kind == DailyLimit) {
+			run = 0
+		}
+	}
+	return run, longest
+}
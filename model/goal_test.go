@@ -0,0 +1,167 @@
+package model
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// TestParseGoal - test goal durations are parsed into whole time slices
+func TestParseGoal(t *testing.T) {
+
+	type testCase struct {
+		goal   string
+		slices int
+		valid  bool
+	}
+
+	testCases := []testCase{
+		{"7h", 28, true},
+		{"2h30m", 10, true},
+		{"2h 30m", 10, true},
+		{"45m", 3, true},
+		{"10m", 1, true}, // rounded up to a time slice
+		{"168h", 672, true},
+		{"169h", 0, false},
+		{"0h", 0, false},
+		{"-2h", 0, false},
+		{"lots", 0, false},
+		{"7", 0, false}}
+	t.Log("Test: parsing goals...")
+	for i, testCase := range testCases {
+		slices, err := ParseGoal(testCase.goal)
+		if (err == nil) != testCase.valid || slices != testCase.slices {
+			t.Errorf("Test: parse goal FAIL - %q is %d slices %v in test case %d", testCase.goal, slices, err, i+1)
+		} else {
+			t.Log("Test: success for parse goal test case " + fmt.Sprint(i+1))
+		}
+	}
+
+	activity := Activity{ID: "1", Name: "Email", DailyGoal: "30m", WeeklyGoal: "lots", Limit: "2h"}
+	if goals := activity.Goals(); fmt.Sprint(goals) != fmt.Sprint([]Goal{{DailyGoal, 2}, {DailyLimit, 8}}) {
+		t.Errorf("Test: parse goal FAIL - activity goals %v", goals)
+	}
+}
+
+// TestGoalState - test how time spent compares to goals and limits, around the boundaries
+func TestGoalState(t *testing.T) {
+
+	type testCase struct {
+		goal   Goal
+		slices int
+		state  GoalState
+		kept   bool
+		text   string
+	}
+
+	sleep := Goal{DailyGoal, 28}
+	email := Goal{DailyLimit, 8}
+	testCases := []testCase{
+		{sleep, 0, GoalBelow, false, "at least 7h a day"},
+		{sleep, 20, GoalBelow, false, ""},
+		{sleep, 21, GoalNear, false, ""},
+		{sleep, 27, GoalNear, false, ""},
+		{sleep, 28, GoalMet, true, ""},
+		{sleep, 40, GoalMet, true, ""},
+		{email, 0, GoalBelow, true, "at most 2h a day"},
+		{email, 5, GoalBelow, true, ""},
+		{email, 6, GoalNear, true, ""},
+		{email, 8, GoalNear, true, ""},
+		{email, 9, GoalExceeded, false, ""},
+		{Goal{WeeklyGoal, 141}, 0, GoalBelow, false, "at least 35h 15m a week"}}
+	t.Log("Test: goal states...")
+	for i, testCase := range testCases {
+		goal := testCase.goal
+		if state := goal.State(testCase.slices); state != testCase.state {
+			t.Errorf("Test: goal state FAIL - state %d in test case %d", state, i+1)
+		} else if goal.Kept(testCase.slices) != testCase.kept {
+			t.Errorf("Test: goal state FAIL - kept in test case %d", i+1)
+		} else if testCase.text != "" && goal.String() != testCase.text {
+			t.Errorf("Test: goal state FAIL - text %q in test case %d", goal.String(), i+1)
+		} else {
+			t.Log("Test: success for goal state test case " + fmt.Sprint(i+1))
+		}
+	}
+}
+
+// TestStreaks - test the streaks of days and weeks goals were kept, including today in progress
+func TestStreaks(t *testing.T) {
+
+	type testCase struct {
+		hours   []int // hours spent on the activity each day, -1 for a day with nothing tracked
+		goal    Goal
+		current int
+		longest int
+	}
+
+	// Monday, October 5, 2020
+	monday := time.Date(2020, 10, 5, 12, 0, 0, 0, time.UTC)
+	daysFor := func(hours []int) []Day {
+		days := []Day{}
+		for i, h := range hours {
+			day := NewDay(monday.AddDate(0, 0, i))
+			if h < 0 {
+				days = append(days, day)
+				continue
+			}
+			day.TimeSlices[95].ActivityID = "other" // the day is tracked
+			for slice := 0; slice < h*4; slice++ {
+				day.TimeSlices[slice].ActivityID = "1"
+			}
+			days = append(days, day)
+		}
+		return days
+	}
+
+	sleep := Goal{DailyGoal, 28}
+	email := Goal{DailyLimit, 8}
+	writing := Goal{WeeklyGoal, 40}
+	testCases := []testCase{
+		{[]int{7, 8, 7}, sleep, 3, 3},
+		{[]int{7, 8, 6}, sleep, 2, 2}, // today isn't over yet
+		{[]int{7, 6, 7, 6}, sleep, 1, 1},
+		{[]int{7, 7, 7, 5, 7, 7}, sleep, 2, 3},
+		{[]int{7, -1, 7}, sleep, 1, 1},
+		{[]int{}, sleep, 0, 0},
+		{[]int{1, 2, 0}, email, 3, 3},
+		{[]int{1, 2, 3}, email, 0, 2},  // over the limit today ends the streak
+		{[]int{1, 2, -1}, email, 2, 2}, // nothing tracked today yet
+		{[]int{1, -1, 1}, email, 1, 1},
+		// two weeks and three days, 10h a week is the goal
+		{[]int{2, 2, 2, 2, 2, 0, 0, 5, 0, 0, 0, 0, 0, 0, 1, 1, 1}, writing, 0, 1},
+		{[]int{2, 2, 2, 2, 2, 0, 0, 5, 5, 0, 0, 0, 0, 0, 10, 1, 1}, writing, 3, 3},
+		{[]int{2, 2, 2, 2, 2, 0, 0, 5, 0, 0, 0, 0, 0, 0, 10, 1, 1}, writing, 1, 1}}
+	t.Log("Test: goal streaks...")
+	for i, testCase := range testCases {
+		current, longest := Streaks(daysFor(testCase.hours), "1", testCase.goal)
+		if current != testCase.current || longest != testCase.longest {
+			t.Errorf("Test: streaks FAIL - current %d longest %d in test case %d", current, longest, i+1)
+		} else {
+			t.Log("Test: success for streaks test case " + fmt.Sprint(i+1))
+		}
+	}
+}
+
+// TestDates - test the calendar days between two times, across daylight saving time
+func TestDates(t *testing.T) {
+	t.Log("Test: dates...")
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("Test: dates FAIL - time zone %v", err)
+	}
+	first := time.Date(2021, 3, 13, 0, 0, 0, 0, newYork)
+	last := time.Date(2021, 3, 15, 23, 59, 0, 0, newYork)
+	dates := []string{}
+	for _, date := range Dates(first, last) {
+		dates = append(dates, date.Format(DateFormat))
+	}
+	if fmt.Sprint(dates) != "[2021-03-13 2021-03-14 2021-03-15]" {
+		t.Errorf("Test: dates FAIL - %v", dates)
+	}
+	if dates := Dates(last, first); len(dates) != 0 {
+		t.Errorf("Test: dates FAIL - last before first %v", dates)
+	}
+	if week := WeekStart(time.Date(2020, 10, 11, 23, 0, 0, 0, time.UTC)); week.Format(DateFormat) != "2020-10-05" {
+		t.Errorf("Test: dates FAIL - the week of a Sunday starts %v", week)
+	}
+}
@@ -0,0 +1,101 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Rule - a time of day spent doing the activity on some days of the week, assigned automatically
+// to a day when it's first shown, e.g. 23:00-7:00 Sleeping every weekday
+type Rule struct {
+	Days     string `json:"days"`     // weekdays, weekends, every day, or days of the week, e.g. mon,wed,fri
+	Time     string `json:"time"`     // 24h times, from the night before when it crosses midnight, e.g. 23:00-7:00
+	Activity string `json:"activity"` // the name of the activity
+}
+
+// Return the days of the week in the text, true for each day the rule applies on, indexed by
+// time.Weekday. Days of the week are named in full or by their first three letters.
+func parseWeekdays(text string) ([7]bool, error) {
+	var weekdays [7]bool
+	switch strings.ToLower(strings.TrimSpace(text)) {
+	case "every day", "daily":
+		return [7]bool{true, true, true, true, true, true, true}, nil
+	case "weekdays":
+		return [7]bool{false, true, true, true, true, true, false}, nil
+	case "weekends":
+		return [7]bool{true, false, false, false, false, false, true}, nil
+	}
+	for _, name := range strings.Split(text, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		found := false
+		for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+			if fullName := strings.ToLower(weekday.String()); name == fullName || name == fullName[:3] {
+				weekdays[weekday], found = true, true
+			}
+		}
+		if !found {
+			return weekdays, fmt.Errorf("%q is not weekdays, weekends, every day, or days of the week, e.g. mon,wed,fri", text)
+		}
+	}
+	return weekdays, nil
+}
+
+// Return the spans of the day of the specified time that the rule assigns. A rule that crosses
+// midnight assigns the end of the days it applies on, and the start of the days after them.
+func (rule Rule) spans(forDay time.Time) ([]Span, error) {
+	weekdays, err := parseWeekdays(rule.Days)
+	if err != nil {
+		return nil, err
+	}
+	span, err := parseClockSpan(rule.Time)
+	if err == nil && span.Start == span.End {
+		err = fmt.Errorf("%q is not a span of the day, e.g. 23:00-7:00", rule.Time)
+	}
+	if err != nil {
+		return nil, err
+	}
+	weekday := forDay.Weekday()
+	if span.Start < span.End {
+		if weekdays[weekday] {
+			return []Span{span}, nil
+		}
+		return []Span{}, nil
+	}
+	spans := []Span{}
+	if weekdays[(weekday+6)%7] { // the night before
+		spans = append(spans, Span{0, span.End})
+	}
+	if weekdays[weekday] {
+		spans = append(spans, Span{span.Start, SlicesPerDay})
+	}
+	return spans, nil
+}
+
+// ApplyRules - assign the time slices of the day that aren't assigned to the activities of the
+// profile's rules, marking them as auto, and return the number of time slices assigned. Where
+// rules overlap the first one wins, and rules with problems are skipped, see ValidateConfig.
+func (profile Profile) ApplyRules(day *Day) (int, error) {
+	forDay, err := day.Time()
+	if err != nil {
+		return 0, err
+	}
+	assigned := 0
+	for _, rule := range profile.Rules {
+		spans, err := rule.spans(forDay)
+		activity, ok := profile.ActivityByName(rule.Activity)
+		if err != nil || !ok {
+			continue
+		}
+		for _, span := range spans {
+			for slice := span.Start; slice < span.End; slice++ {
+				if day.TimeSlices[slice].ActivityID == "" {
+					day.TimeSlices[slice].ActivityID = activity.ID
+					day.TimeSlices[slice].Auto = true
+					assigned++
+				}
+			}
+		}
+	}
+	return assigned, nil
+}
@@ -0,0 +1,62 @@
+package model
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// TestApplyRules - test rules assign the days of the week they name, crossing midnight into the
+// next day, without changing time that's already assigned
+func TestApplyRules(t *testing.T) {
+
+	type testCase struct {
+		date     string
+		assigned string // the activity ID of every hour of the day, . for none
+		count    int
+	}
+
+	sleeping := Activity{ID: "1", Name: "Sleeping", Color: "08b4ff", Active: true}
+	dayJob := Activity{ID: "2", Name: "Day Job", Color: "fffbaa", Active: true}
+	board := Activity{ID: "3", Name: "Board Games", Color: "ffc885", Active: true}
+	profile := Profile{Activities: []Activity{sleeping, dayJob, board}, Rules: []Rule{
+		{"weekdays", "23:00-7:00", "Sleeping"},
+		{"Mon, Tuesday,fri", "9-17", "day job"},
+		{"every day", "6:00-10:00", "Board Games"}, // only where the others haven't assigned time
+		{"sundays", "12-13", "Day Job"},            // a problem, skipped
+		{"daily", "13-14", "Hidden"}}}              // a problem, skipped
+
+	testCases := []testCase{
+		{"2020-10-25", "......3333..............", 16}, // Sunday, the night before isn't a weekday
+		{"2020-10-26", "......33322222222......1", 48}, // Monday, after Sunday night
+		{"2020-10-28", "1111111333.............1", 44}, // Wednesday
+		{"2020-10-30", "11111113322222222......1", 72}, // Friday
+		{"2020-10-31", "1111111333..............", 40}, // Saturday, after Friday night
+		{"2020-11-01", "......3333..............", 16}} // Sunday
+	t.Log("Test: applying rules...")
+	for i, testCase := range testCases {
+		forDay, _ := time.ParseInLocation(DateFormat, testCase.date, time.Local)
+		day := NewDay(forDay)
+		count, err := profile.ApplyRules(&day)
+		assigned := ""
+		for hour := 0; hour < 24; hour++ {
+			timeSlice := day.TimeSlices[hour*4]
+			if timeSlice.ActivityID == "" {
+				assigned += "."
+			} else if timeSlice.Auto {
+				assigned += timeSlice.ActivityID
+			}
+		}
+		if err != nil || count != testCase.count || assigned != testCase.assigned {
+			t.Errorf("Test: apply rules FAIL - assigned %s, %d time slices %v in test case %d", assigned, count, err, i+1)
+		} else {
+			t.Log("Test: success for apply rules test case " + fmt.Sprint(i+1))
+		}
+	}
+
+	day := NewDay(time.Date(2020, 10, 27, 12, 0, 0, 0, time.Local))
+	day.TimeSlices[0].ActivityID = "3"
+	if count, _ := profile.ApplyRules(&day); count != 71 || day.TimeSlices[0].ActivityID != "3" || day.TimeSlices[0].Auto {
+		t.Errorf("Test: apply rules FAIL - assigned time changed, %d time slices", count)
+	}
+}
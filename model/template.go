@@ -0,0 +1,127 @@
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TemplateEntry - a span of a day template and the activity it's spent doing, e.g. 9:00-17:00
+// doing Day Job
+type TemplateEntry struct {
+	Time     string `json:"time"`     // from one time of day to another in 24h time, e.g. 9:00-17:00
+	Activity string `json:"activity"` // the name of the activity
+}
+
+// Spans of the day are from one time to another by hour, or by hour and minute, e.g. 9-17 or 9:00-17:00
+var spanRegExp = regexp.MustCompile(`^([0-9]{1,2})(?::([0-9]{2}))?\s*-\s*([0-9]{1,2})(?::([0-9]{2}))?$`)
+
+// ParseSpan - parse a span of the day from one time to another in 24h time, e.g. 9:00-17:00,
+// which runs from the time slice containing the first time to the one before the second
+func ParseSpan(text string) (Span, error) {
+	span, err := parseClockSpan(text)
+	if err == nil && span.Start >= span.End {
+		return Span{}, fmt.Errorf("%q is not a span of the day, e.g. 9:00-17:00", text)
+	}
+	return span, err
+}
+
+// Parse the times of a span of the day, which end before they start when the span crosses midnight
+func parseClockSpan(text string) (Span, error) {
+	matches := spanRegExp.FindStringSubmatch(strings.TrimSpace(text))
+	if matches == nil {
+		return Span{}, fmt.Errorf("%q is not a span of the day, e.g. 9:00-17:00", text)
+	}
+	start, startOK := clockSlice(matches[1], matches[2])
+	end, endOK := clockSlice(matches[3], matches[4])
+	if !startOK || !endOK {
+		return Span{}, fmt.Errorf("%q is not a span of the day, e.g. 9:00-17:00", text)
+	}
+	return Span{start, end}, nil
+}
+
+// Return the index of the time slice starting at or containing the hour and minute in 24h time,
+// 96 for the end of the day at 24:00, false if it isn't a time of day
+func clockSlice(hourText string, minuteText string) (int, bool) {
+	hour, _ := strconv.Atoi(hourText)
+	minute, _ := strconv.Atoi(minuteText) // 0 when there's no minute
+	if hour > 24 || minute > 59 || (hour == 24 && minute > 0) {
+		return 0, false
+	}
+	return (hour * 4) + (minute / 15), true
+}
+
+// ActivityByName - return the active activity with the name, ignoring case, false if there's none
+func (profile Profile) ActivityByName(name string) (Activity, bool) {
+	for _, activity := range profile.ActiveActivities() {
+		if strings.EqualFold(strings.TrimSpace(activity.Name), strings.TrimSpace(name)) {
+			return activity, true
+		}
+	}
+	return Activity{}, false
+}
+
+// TemplateNames - return the names of the profile's day templates, sorted
+func (profile Profile) TemplateNames() []string {
+	names := []string{}
+	for name := range profile.Templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// TemplateDay - return the day of the specified time with the time slices of the named template
+// assigned to its activities, and the template's name as it's written in the config. The name is
+// matched ignoring case.
+func (profile Profile) TemplateDay(name string, forDay time.Time) (Day, string, error) {
+	day := NewDay(forDay)
+	entries, found := profile.Templates[name]
+	if !found {
+		for _, templateName := range profile.TemplateNames() {
+			if strings.EqualFold(templateName, name) {
+				name, entries, found = templateName, profile.Templates[templateName], true
+				break
+			}
+		}
+	}
+	if !found {
+		return day, name, fmt.Errorf("there's no template named %q in the config", name)
+	}
+	for _, entry := range entries {
+		span, err := ParseSpan(entry.Time)
+		if err != nil {
+			return day, name, fmt.Errorf("the %s template: %w", name, err)
+		}
+		activity, ok := profile.ActivityByName(entry.Activity)
+		if !ok {
+			return day, name, fmt.Errorf("the %s template: there's no active activity named %q", name, entry.Activity)
+		}
+		for slice := span.Start; slice < span.End; slice++ {
+			day.TimeSlices[slice].ActivityID = activity.ID
+		}
+	}
+	return day, name, nil
+}
+
+// Copy - assign the time slices of the day to the activities of the same time slices in the other
+// day, and return the number of time slices changed. Time slices that aren't assigned in the other
+// day are left alone. Unless overwrite is set, only time slices that aren't assigned, or are only
+// assigned by a rule, are changed. The copies are the user's own, not auto.
+func (day *Day) Copy(from Day, overwrite bool) int {
+	copied := 0
+	for i, timeSlice := range from.TimeSlices {
+		current := day.TimeSlices[i]
+		if timeSlice.ActivityID == "" || (timeSlice.ActivityID == current.ActivityID && !current.Auto) ||
+			(current.ActivityID != "" && !current.Auto && !overwrite) {
+			continue
+		}
+		day.TimeSlices[i].ActivityID = timeSlice.ActivityID
+		day.TimeSlices[i].Auto = false
+		copied++
+	}
+	return copied
+}
@@ -0,0 +1,109 @@
+package model
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// TestParseSpan - test spans of the day are parsed into time slices, including the end of the day
+func TestParseSpan(t *testing.T) {
+
+	type testCase struct {
+		text  string
+		span  Span
+		valid bool
+	}
+
+	testCases := []testCase{
+		{"9:00-17:00", Span{36, 68}, true},
+		{"9-17", Span{36, 68}, true},
+		{"0:00 - 7:00", Span{0, 28}, true},
+		{"12:20-13:10", Span{49, 52}, true},
+		{"23-24", Span{92, 96}, true},
+		{"17-9", Span{}, false},
+		{"9-9", Span{}, false},
+		{"9:00-24:15", Span{}, false},
+		{"9:60-10", Span{}, false},
+		{"9:00", Span{}, false},
+		{"", Span{}, false}}
+	t.Log("Test: parsing spans...")
+	for i, testCase := range testCases {
+		span, err := ParseSpan(testCase.text)
+		if (err == nil) != testCase.valid || span != testCase.span {
+			t.Errorf("Test: parse span FAIL - %q is %v %v in test case %d", testCase.text, span, err, i+1)
+		} else {
+			t.Log("Test: success for parse span test case " + fmt.Sprint(i+1))
+		}
+	}
+}
+
+// TestTemplateDay - test day templates are found by name and assign their activities' time slices
+func TestTemplateDay(t *testing.T) {
+	t.Log("Test: template days...")
+	sleeping := Activity{ID: "1", Name: "Sleeping", Color: "08b4ff", Active: true}
+	dayJob := Activity{ID: "2", Name: "Day Job", Color: "fffbaa", Active: true}
+	hidden := Activity{ID: "3", Name: "Hidden", Color: "ff9c9c", Active: false}
+	profile := Profile{Activities: []Activity{sleeping, dayJob, hidden}, Templates: map[string][]TemplateEntry{
+		"workday": {{"0:00-7:00", "sleeping"}, {"9:00-17:00", "Day Job"}},
+		"Weekend": {{"0-9", "Sleeping"}},
+		"broken":  {{"9-17", "Hidden"}},
+	}}
+	date := time.Date(2020, 10, 26, 0, 0, 0, 0, time.UTC)
+
+	day, name, err := profile.TemplateDay("workday", date)
+	if err != nil || name != "workday" || day.Date != "2020-10-26" {
+		t.Fatalf("Test: template days FAIL - workday %q %v", name, err)
+	}
+	if day.TimeSlices[0].ActivityID != "1" || day.TimeSlices[27].ActivityID != "1" || day.TimeSlices[28].ActivityID != "" ||
+		day.TimeSlices[36].ActivityID != "2" || day.TimeSlices[67].ActivityID != "2" || day.TimeSlices[68].ActivityID != "" {
+		t.Errorf("Test: template days FAIL - workday time slices")
+	}
+	if _, name, err := profile.TemplateDay("weekend", date); err != nil || name != "Weekend" {
+		t.Errorf("Test: template days FAIL - name case %q %v", name, err)
+	}
+	if _, _, err := profile.TemplateDay("holiday", date); err == nil {
+		t.Errorf("Test: template days FAIL - missing template found")
+	}
+	if _, _, err := profile.TemplateDay("broken", date); err == nil {
+		t.Errorf("Test: template days FAIL - template with a hidden activity applied")
+	}
+	if names := profile.TemplateNames(); fmt.Sprint(names) != "[Weekend broken workday]" {
+		t.Errorf("Test: template days FAIL - names %v", names)
+	}
+}
+
+// TestCopy - test copying the time slices of another day, merging with and overwriting assigned time
+func TestCopy(t *testing.T) {
+	t.Log("Test: copying days...")
+	from := NewDay(time.Date(2020, 10, 25, 0, 0, 0, 0, time.UTC))
+	for slice := 0; slice < 4; slice++ {
+		from.TimeSlices[slice].ActivityID = "1"
+	}
+	from.TimeSlices[4].Note = "not copied"
+	newDay := func() Day {
+		day := NewDay(time.Date(2020, 10, 26, 0, 0, 0, 0, time.UTC))
+		day.TimeSlices[1].ActivityID = "2"
+		day.TimeSlices[1].Auto = true
+		day.TimeSlices[2].ActivityID = "1"
+		day.TimeSlices[3].Note = "kept"
+		day.TimeSlices[5].ActivityID = "2"
+		return day
+	}
+
+	day := newDay()
+	if copied := day.Copy(from, false); copied != 3 {
+		t.Errorf("Test: copying days FAIL - merged %d time slices", copied)
+	}
+	if day.TimeSlices[0].ActivityID != "1" || day.TimeSlices[1].ActivityID != "1" || day.TimeSlices[1].Auto || day.TimeSlices[3].ActivityID != "1" ||
+		day.TimeSlices[3].Note != "kept" || day.TimeSlices[4].Note != "" || day.TimeSlices[5].ActivityID != "2" {
+		t.Errorf("Test: copying days FAIL - merged time slices %v", day.TimeSlices[:6])
+	}
+	day = newDay()
+	if copied := day.Copy(from, true); copied != 3 {
+		t.Errorf("Test: copying days FAIL - overwrote %d time slices", copied)
+	}
+	if day.TimeSlices[1].ActivityID != "1" || day.TimeSlices[1].Auto || day.TimeSlices[5].ActivityID != "2" || day.Date != "2020-10-26" {
+		t.Errorf("Test: copying days FAIL - overwritten time slices %v", day.TimeSlices[:6])
+	}
+}
@@ -0,0 +1,72 @@
+package model
+
+import "time"
+
+// Timer - an activity being timed live, from when it was started. The time slices it runs
+// through are assigned to the activity as they're completed, see Completed.
+type Timer struct {
+	ActivityID string    // blank when the timer isn't running
+	Start      time.Time // when the timer was started
+	Through    time.Time // the end of the time slices assigned so far, zero until one is
+}
+
+// DaySpan - a span of the time slices of the day of a time
+type DaySpan struct {
+	Day time.Time
+	Span
+}
+
+// Running - return true if the timer is timing an activity
+func (timer Timer) Running() bool {
+	return timer.ActivityID != ""
+}
+
+// Same - return true if the other timer is timing the same activity from the same start, however
+// far either has been assigned through
+func (timer Timer) Same(other Timer) bool {
+	return timer.ActivityID == other.ActivityID && timer.Start.Equal(other.Start)
+}
+
+// Completed - return the spans of each day the timer has run through as of now that haven't been
+// assigned to the activity yet, oldest first, and the time they end at, the timer's next Through.
+// The timer starts at the time slice boundary nearest to its start, and only complete time slices
+// are returned, unless it's stopping, when the time slice in progress is if it's more than half
+// way through.
+func (timer Timer) Completed(now time.Time, stopping bool) ([]DaySpan, time.Time) {
+	spans := []DaySpan{}
+	if !timer.Running() {
+		return spans, timer.Through
+	}
+	// In the time zone of now, as stored times may not be
+	from := timer.Start.In(now.Location()).Round(SliceDuration)
+	if timer.Through.After(from) {
+		from = timer.Through.In(now.Location())
+	}
+	through := now.Truncate(SliceDuration)
+	if stopping {
+		through = now.Round(SliceDuration)
+	}
+	if !through.After(from) {
+		return spans, timer.Through
+	}
+	for t := from; t.Before(through); t = t.Add(SliceDuration) {
+		slice := SliceForTime(t)
+		last := len(spans) - 1
+		if last >= 0 && spans[last].Day.Format(DateFormat) == t.Format(DateFormat) && spans[last].End == slice {
+			spans[last].End++
+		} else {
+			spans = append(spans, DaySpan{t, Span{slice, slice + 1}})
+		}
+	}
+	return spans, through
+}
+
+// Assign - assign the activity to every time slice in the span, replacing any time assigned to
+// other activities, including by rules, and return the number of time slices assigned
+func (day *Day) Assign(span Span, activityID string) int {
+	for slice := span.Start; slice < span.End; slice++ {
+		day.TimeSlices[slice].ActivityID = activityID
+		day.TimeSlices[slice].Auto = false
+	}
+	return span.Slices()
+}
@@ -0,0 +1,70 @@
+package model
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// TestTimerCompleted - test the time slices a timer has completed, rounding its start and stop to
+// the nearest time slice, across midnight, and without repeating those already assigned
+func TestTimerCompleted(t *testing.T) {
+
+	type testCase struct {
+		start    time.Time
+		through  time.Time
+		now      time.Time
+		stopping bool
+		spans    string
+	}
+
+	at := func(day int, hour int, minute int) time.Time {
+		return time.Date(2020, 10, day, hour, minute, 0, 0, time.Local)
+	}
+	testCases := []testCase{
+		{at(26, 9, 5), time.Time{}, at(26, 9, 14), false, "[]"},
+		{at(26, 9, 5), time.Time{}, at(26, 9, 15), false, "[2020-10-26 9:00 - 9:15]"},
+		{at(26, 9, 8), time.Time{}, at(26, 10, 20), false, "[2020-10-26 9:15 - 10:15]"},
+		{at(26, 9, 8), time.Time{}, at(26, 10, 20), true, "[2020-10-26 9:15 - 10:15]"},
+		{at(26, 9, 8), time.Time{}, at(26, 10, 23), true, "[2020-10-26 9:15 - 10:30]"},
+		{at(26, 9, 0), at(26, 10, 0), at(26, 10, 50), false, "[2020-10-26 10:00 - 10:45]"},
+		{at(26, 9, 0), at(26, 10, 0), at(26, 10, 10), false, "[]"},
+		{at(26, 9, 0), time.Time{}, at(26, 9, 2), true, "[]"},
+		{at(26, 9, 0).UTC(), at(26, 10, 0).UTC(), at(26, 10, 30), false, "[2020-10-26 10:00 - 10:30]"},
+		{at(26, 23, 30), time.Time{}, at(27, 0, 30), false, "[2020-10-26 23:30 - 24:00 2020-10-27 0:00 - 0:30]"},
+		{at(25, 22, 0), time.Time{}, at(27, 1, 0), false, "[2020-10-25 22:00 - 24:00 2020-10-26 0:00 - 24:00 2020-10-27 0:00 - 1:00]"}}
+	t.Log("Test: timers...")
+	for i, testCase := range testCases {
+		timer := Timer{ActivityID: "1", Start: testCase.start, Through: testCase.through}
+		spans, through := timer.Completed(testCase.now, testCase.stopping)
+		texts := []string{}
+		for _, span := range spans {
+			texts = append(texts, span.Day.Format(DateFormat)+" "+span.String())
+		}
+		expectedThrough := testCase.through
+		if len(spans) > 0 {
+			last := spans[len(spans)-1]
+			expectedThrough = time.Date(last.Day.Year(), last.Day.Month(), last.Day.Day(), 0, 0, 0, 0, time.Local).Add(time.Duration(last.End) * SliceDuration)
+		}
+		if fmt.Sprint(texts) != testCase.spans || !through.Equal(expectedThrough) {
+			t.Errorf("Test: timer FAIL - %v through %v in test case %d", texts, through, i+1)
+		} else {
+			t.Log("Test: success for timer test case " + fmt.Sprint(i+1))
+		}
+	}
+
+	timer := Timer{ActivityID: "1", Start: at(26, 9, 0)}
+	if !timer.Same(Timer{ActivityID: "1", Start: at(26, 9, 0).UTC(), Through: at(26, 10, 0)}) ||
+		timer.Same(Timer{ActivityID: "2", Start: at(26, 9, 0)}) || timer.Same(Timer{}) {
+		t.Errorf("Test: timer FAIL - same timers")
+	}
+	if spans, _ := (Timer{}).Completed(at(26, 10, 0), true); len(spans) != 0 {
+		t.Errorf("Test: timer FAIL - a stopped timer completed %v", spans)
+	}
+	day := NewDay(at(26, 0, 0))
+	day.TimeSlices[36] = TimeSlice{Slice: 36, ActivityID: "2", Note: "kept", Auto: true}
+	if assigned := day.Assign(Span{36, 38}, "1"); assigned != 2 || day.TimeSlices[36].ActivityID != "1" ||
+		day.TimeSlices[36].Auto || day.TimeSlices[36].Note != "kept" || day.TimeSlices[38].ActivityID != "" {
+		t.Errorf("Test: timer FAIL - assigned %d time slices %v", assigned, day.TimeSlices[36:39])
+	}
+}
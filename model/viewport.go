@@ -0,0 +1,102 @@
+package model
+
+import "time"
+
+// Anchor - the edge of a viewport that stays on the same time slice when the viewport is resized
+type Anchor int
+
+const (
+	// AnchorStart - keep the first time slice, e.g. after paging or going to a time
+	AnchorStart Anchor = iota
+	// AnchorEnd - keep the last time slice, e.g. when showing up to the current time
+	AnchorEnd
+)
+
+// Viewport - the window of consecutive time slices of a day that are shown, and numbered t1 to
+// t<Size>. A viewport always fits in the day: 1 <= Size <= SlicesPerDay, 0 <= Start <= SlicesPerDay-Size.
+type Viewport struct {
+	Start  int    // index in the day of the first time slice shown
+	Size   int    // the number of time slices shown
+	Anchor Anchor // the edge that stays put when the size changes
+}
+
+// ViewportStartingAt - return a viewport of the size, starting with the time slice of the day,
+// or as close to it as fits in the day
+func ViewportStartingAt(slice int, size int) Viewport {
+	return Viewport{Start: slice, Size: size, Anchor: AnchorStart}.fit()
+}
+
+// ViewportEndingAt - return a viewport of the size, ending with the time slice of the day,
+// or as close to it as fits in the day
+func ViewportEndingAt(slice int, size int) Viewport {
+	size = fitSize(size)
+	return Viewport{Start: slice - size + 1, Size: size, Anchor: AnchorEnd}.fit()
+}
+
+// ViewportForTime - return a viewport of the size, ending with the time slice containing the time
+func ViewportForTime(t time.Time, size int) Viewport {
+	return ViewportEndingAt(SliceForTime(t), size)
+}
+
+// End - return the index in the day of the last time slice shown
+func (viewport Viewport) End() int {
+	return viewport.Start + viewport.Size - 1
+}
+
+// Contains - return true if the time slice of the day is shown
+func (viewport Viewport) Contains(slice int) bool {
+	return slice >= viewport.Start && slice <= viewport.End()
+}
+
+// Next - return the page after the viewport, starting with the time slice after its last one,
+// so pages don't overlap, and stopping at the end of the day
+func (viewport Viewport) Next() Viewport {
+	return ViewportStartingAt(viewport.End()+1, viewport.Size)
+}
+
+// Prior - return the page before the viewport, ending with the time slice before its first one,
+// so pages don't overlap, and stopping at the start of the day
+func (viewport Viewport) Prior() Viewport {
+	return ViewportStartingAt(viewport.Start-viewport.Size, viewport.Size)
+}
+
+// Scroll - return the viewport moved by the number of time slices, later in the day for a
+// positive number, earlier for a negative number, keeping its anchor
+func (viewport Viewport) Scroll(slices int) Viewport {
+	viewport.Start += slices
+	return viewport.fit()
+}
+
+// Resize - return the viewport with the size, keeping the time slice at its anchor
+func (viewport Viewport) Resize(size int) Viewport {
+	if viewport.Anchor == AnchorEnd {
+		return ViewportEndingAt(viewport.End(), size)
+	}
+	return ViewportStartingAt(viewport.Start, size)
+}
+
+// TimeSlices - return the time slices of the day that are shown
+func (viewport Viewport) TimeSlices(day *Day) []TimeSlice {
+	return day.TimeSlices[viewport.Start : viewport.End()+1]
+}
+
+// Return the viewport adjusted to fit in the day, near the start and end of the day
+func (viewport Viewport) fit() Viewport {
+	viewport.Size = fitSize(viewport.Size)
+	if viewport.Start < 0 {
+		viewport.Start = 0
+	} else if viewport.Start > SlicesPerDay-viewport.Size {
+		viewport.Start = SlicesPerDay - viewport.Size
+	}
+	return viewport
+}
+
+// Return the size adjusted to at least one time slice, and at most the whole day
+func fitSize(size int) int {
+	if size < 1 {
+		return 1
+	} else if size > SlicesPerDay {
+		return SlicesPerDay
+	}
+	return size
+}
@@ -0,0 +1,198 @@
+package model
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// TestViewportForTime - test the viewport ending at the current time, at every quarter-hour offset, near
+// midnight, on hour boundaries, and on the days daylight saving time starts and ends
+func TestViewportForTime(t *testing.T) {
+
+	type testCase struct {
+		now       time.Time
+		displayed int
+		first     int // index in the day of the first time slice in the window
+		slice     int // index in the day of the time slice containing now
+	}
+
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("Test: time slices for time FAIL - time zone %v", err)
+	}
+	at := func(hour int, minute int, second int) time.Time {
+		return time.Date(2020, 10, 26, hour, minute, second, 0, time.UTC)
+	}
+	// Clocks go forward from 2:00 to 3:00 on 3/14, and back from 2:00 to 1:00 on 11/7
+	springForward := time.Date(2021, 3, 14, 1, 59, 0, 0, newYork).Add(time.Minute)
+	fallBack := time.Date(2021, 11, 7, 1, 30, 0, 0, newYork)
+
+	testCases := []testCase{
+		// midnight
+		{at(0, 0, 0), 12, 0, 0},
+		{at(0, 5, 0), 12, 0, 0},
+		{at(2, 55, 0), 12, 0, 11},
+		{at(23, 44, 0), 12, 83, 94},
+		{at(23, 50, 0), 12, 84, 95},
+		{at(23, 59, 59), 12, 84, 95},
+		{at(23, 50, 0), 1, 95, 95},
+		{at(12, 0, 0), 96, 0, 48},
+		// hour boundaries
+		{at(3, 0, 0), 12, 1, 12},
+		{at(9, 59, 59), 12, 28, 39},
+		{at(10, 0, 0), 12, 29, 40},
+		{at(10, 14, 59), 12, 29, 40},
+		{at(10, 15, 0), 12, 30, 41},
+		// daylight saving time, time slices follow the wall clock
+		{springForward.Add(-time.Minute), 12, 0, 7},
+		{springForward, 12, 1, 12},
+		{springForward.Add(5 * time.Minute), 12, 1, 12},
+		{fallBack, 12, 0, 6},
+		{fallBack.Add(time.Hour), 12, 0, 6}, // the repeated hour shares its time slices
+		{fallBack.Add(2 * time.Hour), 12, 0, 10},
+		{fallBack.Add(3*time.Hour + 30*time.Minute), 12, 5, 16}}
+	t.Log("Test: time slices for time...")
+	for i, testCase := range testCases {
+		viewport := ViewportForTime(testCase.now, testCase.displayed)
+		slice := SliceForTime(testCase.now)
+		if viewport.Size != testCase.displayed || viewport.Start != testCase.first || viewport.Anchor != AnchorEnd {
+			t.Errorf("Test: time slices for time FAIL - window %+v in test case %d", viewport, i+1)
+		} else if slice != testCase.slice {
+			t.Errorf("Test: time slices for time FAIL - now is slice %d in test case %d", slice, i+1)
+		} else if !viewport.Contains(slice) {
+			t.Errorf("Test: time slices for time FAIL - now isn't shown in test case %d", i+1)
+		} else {
+			t.Log("Test: success for time slices for time test case " + fmt.Sprint(i+1))
+		}
+	}
+}
+
+// TestViewportEveryMinute - test the viewport for the current time ends with the time slice
+// containing it, whatever the quarter-hour offset, until it would start before midnight
+func TestViewportEveryMinute(t *testing.T) {
+	t.Log("Test: viewport for every minute of the day...")
+	midnight := time.Date(2020, 10, 26, 0, 0, 0, 0, time.UTC)
+	for minute := 0; minute < 24*60; minute++ {
+		now := midnight.Add(time.Duration(minute) * time.Minute)
+		viewport := ViewportForTime(now, 12)
+		slice := minute / 15
+		if (slice >= 11 && viewport.End() != slice) || (slice < 11 && viewport.Start != 0) || !viewport.Contains(slice) {
+			t.Errorf("Test: viewport for every minute FAIL - %+v at %s", viewport, now.Format("15:04"))
+		}
+	}
+}
+
+// TestViewportPaging - test paging, scrolling and going to a time slice, at the start and
+// end of the day, so pages neither overlap nor go past midnight
+func TestViewportPaging(t *testing.T) {
+
+	type testCase struct {
+		viewport Viewport
+		move     func(Viewport) Viewport
+		start    int // index in the day of the first time slice after the move
+		end      int // index in the day of the last time slice after the move
+	}
+
+	next := func(viewport Viewport) Viewport { return viewport.Next() }
+	prior := func(viewport Viewport) Viewport { return viewport.Prior() }
+	scroll := func(slices int) func(Viewport) Viewport {
+		return func(viewport Viewport) Viewport { return viewport.Scroll(slices) }
+	}
+
+	testCases := []testCase{
+		// next page starts after the last time slice
+		{ViewportStartingAt(0, 12), next, 12, 23},
+		{ViewportStartingAt(72, 12), next, 84, 95},
+		{ViewportStartingAt(80, 12), next, 84, 95},
+		{ViewportStartingAt(84, 12), next, 84, 95},
+		{ViewportForTime(time.Date(2020, 10, 26, 10, 5, 0, 0, time.UTC), 12), next, 41, 52},
+		// prior page ends before the first time slice
+		{ViewportStartingAt(24, 12), prior, 12, 23},
+		{ViewportStartingAt(12, 12), prior, 0, 11},
+		{ViewportStartingAt(5, 12), prior, 0, 11},
+		{ViewportStartingAt(0, 12), prior, 0, 11},
+		// a page of one time slice, and of the whole day
+		{ViewportStartingAt(94, 1), next, 95, 95},
+		{ViewportStartingAt(95, 1), next, 95, 95},
+		{ViewportStartingAt(1, 1), prior, 0, 0},
+		{ViewportStartingAt(0, 96), next, 0, 95},
+		{ViewportStartingAt(0, 96), prior, 0, 95},
+		// scrolling by a time slice or an hour
+		{ViewportStartingAt(10, 12), scroll(1), 11, 22},
+		{ViewportStartingAt(10, 12), scroll(-4), 6, 17},
+		{ViewportStartingAt(2, 12), scroll(-4), 0, 11},
+		{ViewportStartingAt(83, 12), scroll(4), 84, 95},
+		// going to a time slice
+		{ViewportStartingAt(-1, 12), scroll(0), 0, 11},
+		{ViewportStartingAt(90, 12), scroll(0), 84, 95},
+		{ViewportStartingAt(96, 12), scroll(0), 84, 95},
+		{ViewportEndingAt(5, 12), scroll(0), 0, 11},
+		{ViewportEndingAt(95, 12), scroll(0), 84, 95}}
+	t.Log("Test: viewport paging...")
+	for i, testCase := range testCases {
+		viewport := testCase.move(testCase.viewport)
+		if viewport.Start != testCase.start || viewport.End() != testCase.end || viewport.Size != testCase.viewport.Size {
+			t.Errorf("Test: viewport paging FAIL - %+v in test case %d", viewport, i+1)
+		} else {
+			t.Log("Test: success for viewport paging test case " + fmt.Sprint(i+1))
+		}
+	}
+
+	// paging through the day shows each time slice once
+	shown := 0
+	for viewport := ViewportStartingAt(0, 12); ; viewport = viewport.Next() {
+		shown += viewport.Size
+		if viewport.End() == SlicesPerDay-1 {
+			break
+		}
+	}
+	if shown != SlicesPerDay {
+		t.Errorf("Test: viewport paging FAIL - paging through the day shows %d time slices", shown)
+	}
+}
+
+// TestViewportResize - test resizing keeps the time slice at the anchor, and fits in the day
+func TestViewportResize(t *testing.T) {
+
+	type testCase struct {
+		viewport Viewport
+		size     int
+		start    int // index in the day of the first time slice after resizing
+		end      int // index in the day of the last time slice after resizing
+	}
+
+	testCases := []testCase{
+		{ViewportStartingAt(36, 12), 20, 36, 55},
+		{ViewportStartingAt(36, 12), 4, 36, 39},
+		{ViewportStartingAt(80, 12), 20, 76, 95},
+		{ViewportEndingAt(47, 12), 20, 28, 47},
+		{ViewportEndingAt(47, 12), 4, 44, 47},
+		{ViewportEndingAt(10, 12), 20, 0, 19},
+		{ViewportEndingAt(47, 12), 96, 0, 95},
+		{ViewportStartingAt(36, 12), 0, 36, 36},
+		{ViewportStartingAt(36, 12), 200, 0, 95}}
+	t.Log("Test: viewport resize...")
+	for i, testCase := range testCases {
+		viewport := testCase.viewport.Resize(testCase.size)
+		if viewport.Start != testCase.start || viewport.End() != testCase.end || viewport.Anchor != testCase.viewport.Anchor {
+			t.Errorf("Test: viewport resize FAIL - %+v in test case %d", viewport, i+1)
+		} else {
+			t.Log("Test: success for viewport resize test case " + fmt.Sprint(i+1))
+		}
+	}
+}
+
+// TestViewportTimeSlices - test the time slices shown are the day's, so changing them changes the day
+func TestViewportTimeSlices(t *testing.T) {
+	t.Log("Test: viewport time slices...")
+	day := NewDay(time.Date(2020, 10, 26, 0, 0, 0, 0, time.UTC))
+	timeSlices := ViewportStartingAt(84, 12).TimeSlices(&day)
+	if len(timeSlices) != 12 || timeSlices[0].Slice != 84 || timeSlices[11].Slice != 95 {
+		t.Errorf("Test: viewport time slices FAIL - %v", timeSlices)
+	}
+	timeSlices[11].ActivityID = "1"
+	if day.TimeSlices[95].ActivityID != "1" {
+		t.Errorf("Test: viewport time slices FAIL - the day didn't change")
+	}
+}
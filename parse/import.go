@@ -0,0 +1,253 @@
+package parse
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/seven-serverless-projects/bt/model"
+)
+
+// Supported rounding rules for fitting imported entries into 15m time slices
+const (
+	RoundNearest = "nearest" // round the start and end to the closest slice boundary
+	RoundExpand  = "expand"  // round the start down and the end up, covering any partial slice
+	RoundShrink  = "shrink"  // round the start up and the end down, covering only full slices
+)
+
+// TogglLayouts - date and time layouts used by the Toggl CSV export
+var TogglLayouts = []string{"2006-01-02 15:04:05"}
+
+// ClockifyLayouts - date and time layouts used by the Clockify CSV export, which vary by the user's
+// settings. One layout is used for the whole export, see ParseCSVExport.
+var ClockifyLayouts = []string{
+	"01/02/2006 03:04:05 PM",
+	"01/02/2006 03:04 PM",
+	"01/02/2006 15:04:05",
+	"01/02/2006 15:04",
+	"02/01/2006 15:04:05",
+	"2006-01-02 15:04:05",
+}
+
+// Time layout used by Timewarrior's JSON export
+const timewarriorLayout = "20060102T150405Z"
+
+// ImportEntry - a single span of time spent doing a named activity, from another time tracker
+type ImportEntry struct {
+	Activity string
+	Start    time.Time
+	End      time.Time
+}
+
+// Timewarrior's JSON representation of a tracked interval
+type timewarriorInterval struct {
+	Start string   `json:"start"`
+	End   string   `json:"end"`
+	Tags  []string `json:"tags"`
+}
+
+// ParseExport - parse the time entries from the export of another time tracker,
+// format is one of toggl, clockify or timewarrior
+func ParseExport(format string, reader io.Reader) ([]ImportEntry, error) {
+	switch format {
+	case "toggl":
+		return ParseCSVExport(reader, TogglLayouts)
+	case "clockify":
+		return ParseCSVExport(reader, ClockifyLayouts)
+	case "timewarrior", "timew":
+		return ParseTimewarriorExport(reader)
+	}
+	return []ImportEntry{}, fmt.Errorf("unknown import format: %s", format)
+}
+
+// ParseCSVExport - parse a CSV export with a header row containing Project, Description, Start date,
+// Start time, End date and End time columns, such as those from Toggl and Clockify. Every date and
+// time must be in the same one of the layouts, and it's an error if they fit more than one that
+// read them differently, e.g. 03/04/2020 month first and day first, so no time lands on the wrong day.
+func ParseCSVExport(reader io.Reader, layouts []string) ([]ImportEntry, error) {
+	entries := []ImportEntry{}
+	records, err := csv.NewReader(reader).ReadAll()
+	if err != nil {
+		return entries, err
+	}
+	if len(records) == 0 {
+		return entries, errors.New("empty CSV export")
+	}
+
+	// Find the columns we need from the header, regardless of case
+	columns := make(map[string]int)
+	for i, name := range records[0] {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range []string{"project", "start date", "start time", "end date", "end time"} {
+		if _, ok := columns[name]; !ok {
+			return entries, fmt.Errorf("missing CSV column: %s", name)
+		}
+	}
+	column := func(record []string, name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	activities, values := []string{}, []string{} // the start and end of each entry, in pairs
+	for i, record := range records[1:] {
+		activity := column(record, "project")
+		if activity == "" {
+			activity = column(record, "description")
+		}
+		if activity == "" {
+			continue // nothing to label the time with
+		}
+		start := column(record, "start date") + " " + column(record, "start time")
+		end := column(record, "end date") + " " + column(record, "end time")
+		for _, value := range []string{start, end} {
+			if _, err := parseImportTime(value, layouts); err != nil {
+				return entries, fmt.Errorf("row %d: %v", i+2, err)
+			}
+		}
+		activities, values = append(activities, activity), append(values, start, end)
+	}
+
+	layout, err := exportLayout(values, layouts)
+	if err != nil {
+		return entries, err
+	}
+	for i, activity := range activities {
+		start, _ := time.ParseInLocation(layout, values[i*2], time.Local)
+		end, _ := time.ParseInLocation(layout, values[(i*2)+1], time.Local)
+		entries = append(entries, ImportEntry{activity, start, end})
+	}
+	return entries, nil
+}
+
+// Return the first of the layouts every one of the dates and times is in, an error if there
+// isn't one, or if another layout fits them all too and reads any of them differently
+func exportLayout(values []string, layouts []string) (string, error) {
+	if len(values) == 0 {
+		return "", nil
+	}
+	fits := []string{}
+	for _, layout := range layouts {
+		fit := true
+		for _, value := range values {
+			if _, err := time.ParseInLocation(layout, value, time.Local); err != nil {
+				fit = false
+				break
+			}
+		}
+		if fit {
+			fits = append(fits, layout)
+		}
+	}
+	if len(fits) == 0 {
+		return "", errors.New("the dates and times aren't all in the same format")
+	}
+	for _, other := range fits[1:] {
+		for _, value := range values {
+			first, _ := time.ParseInLocation(fits[0], value, time.Local)
+			second, _ := time.ParseInLocation(other, value, time.Local)
+			if !first.Equal(second) {
+				return "", fmt.Errorf("ambiguous date %s could be %s or %s, export with the date as YYYY-MM-DD instead",
+					value, first.Format("January 2, 2006"), second.Format("January 2, 2006"))
+			}
+		}
+	}
+	return fits[0], nil
+}
+
+// ParseTimewarriorExport - parse the output of `timew export`, using the first tag of each interval as the activity
+func ParseTimewarriorExport(reader io.Reader) ([]ImportEntry, error) {
+	entries := []ImportEntry{}
+	contents, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return entries, err
+	}
+	intervals := []timewarriorInterval{}
+	err = json.Unmarshal(contents, &intervals)
+	if err != nil {
+		return entries, err
+	}
+	for i, interval := range intervals {
+		if interval.End == "" || len(interval.Tags) == 0 {
+			continue // still running, or nothing to label the time with
+		}
+		start, err := time.Parse(timewarriorLayout, interval.Start)
+		if err != nil {
+			return entries, fmt.Errorf("interval %d: %v", i+1, err)
+		}
+		end, err := time.Parse(timewarriorLayout, interval.End)
+		if err != nil {
+			return entries, fmt.Errorf("interval %d: %v", i+1, err)
+		}
+		entries = append(entries, ImportEntry{interval.Tags[0], start.Local(), end.Local()})
+	}
+	return entries, nil
+}
+
+// Parse a local date and time with the first of the layouts that matches
+func parseImportTime(value string, layouts []string) (time.Time, error) {
+	for _, layout := range layouts {
+		parsed, err := time.ParseInLocation(layout, value, time.Local)
+		if err == nil {
+			return parsed, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized date and time: %s", value)
+}
+
+// Round the start and end of an entry to slice boundaries with the specified rounding rule
+func roundEntry(entry ImportEntry, rounding string) (time.Time, time.Time, error) {
+	start, end := entry.Start, entry.End
+	switch rounding {
+	case RoundNearest:
+		start, end = start.Round(model.SliceDuration), end.Round(model.SliceDuration)
+	case RoundExpand:
+		start, end = start.Truncate(model.SliceDuration), roundUp(end)
+	case RoundShrink:
+		start, end = roundUp(start), end.Truncate(model.SliceDuration)
+	default:
+		return start, end, fmt.Errorf("unknown rounding rule: %s", rounding)
+	}
+	return start, end, nil
+}
+
+// Round the time up to the next slice boundary, unless it's already on one
+func roundUp(t time.Time) time.Time {
+	truncated := t.Truncate(model.SliceDuration)
+	if truncated.Equal(t) {
+		return t
+	}
+	return truncated.Add(model.SliceDuration)
+}
+
+// ImportTimeSlices - given the imported entries, return a map of dates to the time slices that
+// were spent on an activity, using activityID to find (or create) the ID for each activity name
+func ImportTimeSlices(entries []ImportEntry, rounding string, activityID func(name string) (string, error)) (map[string]map[int]string, error) {
+	days := make(map[string]map[int]string)
+	for _, entry := range entries {
+		start, end, err := roundEntry(entry, rounding)
+		if err != nil {
+			return days, err
+		}
+		id, err := activityID(entry.Activity)
+		if err != nil {
+			return days, err
+		}
+		for t := start; t.Before(end); t = t.Add(model.SliceDuration) {
+			date := t.Format(model.DateFormat)
+			if days[date] == nil {
+				days[date] = make(map[int]string)
+			}
+			days[date][model.SliceForTime(t)] = id
+		}
+	}
+	return days, nil
+}
@@ -0,0 +1,160 @@
+package parse
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestParseCSVExport - test parsing Toggl and Clockify CSV exports
+func TestParseCSVExport(t *testing.T) {
+
+	type testCase struct {
+		export   string
+		layouts  []string
+		activity string
+		start    time.Time
+		end      time.Time
+		err      bool
+	}
+
+	testCases := []testCase{
+		// failure cases
+		{"", TogglLayouts, "", time.Time{}, time.Time{}, parseFailure},
+		{"Project,Start date,Start time\nWriting,2020-10-26,09:00:00", TogglLayouts, "", time.Time{}, time.Time{}, parseFailure},
+		{"Project,Start date,Start time,End date,End time\nWriting,10/26/2020,09:00:00,2020-10-26,10:00:00", TogglLayouts, "", time.Time{}, time.Time{}, parseFailure},
+		{"Project,Start date,Start time,End date,End time\nWriting,03/04/2020,09:00:00,03/04/2020,10:00:00", ClockifyLayouts, "", time.Time{}, time.Time{}, parseFailure},
+		{"Project,Start date,Start time,End date,End time\nWriting,10/26/2020,09:00,2020-10-26,10:00:00", ClockifyLayouts, "", time.Time{}, time.Time{}, parseFailure},
+		// success cases
+		{"User,Email,Client,Project,Task,Description,Billable,Start date,Start time,End date,End time,Duration\n" +
+			"Albert,albert@example.com,,Writing,,Chapter 4,No,2020-10-26,09:00:00,2020-10-26,10:07:00,01:07:00",
+			TogglLayouts, "Writing",
+			time.Date(2020, 10, 26, 9, 0, 0, 0, time.Local), time.Date(2020, 10, 26, 10, 7, 0, 0, time.Local), parseSuccess},
+		{"Project,Client,Description,Start Date,Start Time,End Date,End Time\n" +
+			",,Reading,10/26/2020,11:30:00 PM,10/27/2020,12:15:00 AM",
+			ClockifyLayouts, "Reading",
+			time.Date(2020, 10, 26, 23, 30, 0, 0, time.Local), time.Date(2020, 10, 27, 0, 15, 0, 0, time.Local), parseSuccess},
+		{"Project,Client,Description,Start Date,Start Time,End Date,End Time\n" +
+			"Day Job,,,10/26/2020,13:00,10/26/2020,17:00",
+			ClockifyLayouts, "Day Job",
+			time.Date(2020, 10, 26, 13, 0, 0, 0, time.Local), time.Date(2020, 10, 26, 17, 0, 0, 0, time.Local), parseSuccess}}
+	t.Log("Test: parsing CSV exports...")
+	for i, testCase := range testCases {
+		entries, err := ParseCSVExport(strings.NewReader(testCase.export), testCase.layouts)
+		if (err != nil) != testCase.err {
+			t.Errorf("Test: parse CSV FAIL - parse outcome in test case %d", i+1)
+		} else if err != nil {
+			t.Log("Test: success for CSV test case " + fmt.Sprint(i+1))
+		} else if len(entries) != 1 || entries[0].Activity != testCase.activity {
+			t.Errorf("Test: parse CSV FAIL - activity in test case %d", i+1)
+		} else if !entries[0].Start.Equal(testCase.start) || !entries[0].End.Equal(testCase.end) {
+			t.Errorf("Test: parse CSV FAIL - times in test case %d", i+1)
+		} else {
+			t.Log("Test: success for CSV test case " + fmt.Sprint(i+1))
+		}
+	}
+
+	// A day first export is read day first throughout, by the dates after the 12th
+	export := "Project,Start date,Start time,End date,End time\n" +
+		"Writing,03/04/2020,09:00:00,03/04/2020,10:00:00\n" +
+		"Reading,26/04/2020,09:00:00,26/04/2020,10:00:00"
+	entries, err := ParseCSVExport(strings.NewReader(export), ClockifyLayouts)
+	if err != nil || len(entries) != 2 || !entries[0].Start.Equal(time.Date(2020, 4, 3, 9, 0, 0, 0, time.Local)) ||
+		!entries[1].Start.Equal(time.Date(2020, 4, 26, 9, 0, 0, 0, time.Local)) {
+		t.Errorf("Test: parse CSV FAIL - day first export %v %v", entries, err)
+	}
+}
+
+// TestParseTimewarriorExport - test parsing the JSON from timew export
+func TestParseTimewarriorExport(t *testing.T) {
+	export := `[
+		{"id":3,"start":"20201026T090000Z","end":"20201026T101500Z","tags":["Writing","book"]},
+		{"id":2,"start":"20201026T120000Z","end":"20201026T130000Z","tags":[]},
+		{"id":1,"start":"20201026T140000Z","tags":["Reading"]}
+	]`
+	t.Log("Test: parsing Timewarrior export...")
+	entries, err := ParseTimewarriorExport(strings.NewReader(export))
+	if err != nil {
+		t.Errorf("Test: parse Timewarrior FAIL - %v", err)
+	} else if len(entries) != 1 {
+		t.Errorf("Test: parse Timewarrior FAIL - expected 1 entry, got %d", len(entries))
+	} else if entries[0].Activity != "Writing" ||
+		!entries[0].Start.Equal(time.Date(2020, 10, 26, 9, 0, 0, 0, time.UTC)) ||
+		!entries[0].End.Equal(time.Date(2020, 10, 26, 10, 15, 0, 0, time.UTC)) {
+		t.Errorf("Test: parse Timewarrior FAIL - entry %v", entries[0])
+	}
+	_, err = ParseTimewarriorExport(strings.NewReader(`{"start": "soon"}`))
+	if err == nil {
+		t.Errorf("Test: parse Timewarrior FAIL - invalid export parsed")
+	}
+}
+
+// TestImportTimeSlices - test rounding imported entries into time slices
+func TestImportTimeSlices(t *testing.T) {
+
+	type testCase struct {
+		rounding string
+		start    time.Time
+		end      time.Time
+		slices   map[string][]int
+		err      bool
+	}
+
+	lookups := 0
+	activityID := func(name string) (string, error) {
+		lookups++
+		return "writing-id", nil
+	}
+
+	at := func(day int, hour int, minute int) time.Time {
+		return time.Date(2020, 10, day, hour, minute, 0, 0, time.Local)
+	}
+
+	testCases := []testCase{
+		// failure cases
+		{"sideways", at(26, 9, 0), at(26, 10, 0), nil, parseFailure},
+		// success cases
+		{RoundNearest, at(26, 9, 0), at(26, 9, 45), map[string][]int{"2020-10-26": {36, 37, 38}}, parseSuccess},
+		{RoundNearest, at(26, 9, 7), at(26, 9, 37), map[string][]int{"2020-10-26": {36, 37}}, parseSuccess},
+		{RoundExpand, at(26, 9, 7), at(26, 9, 38), map[string][]int{"2020-10-26": {36, 37, 38}}, parseSuccess},
+		{RoundShrink, at(26, 9, 7), at(26, 9, 38), map[string][]int{"2020-10-26": {37}}, parseSuccess},
+		{RoundShrink, at(26, 9, 7), at(26, 9, 20), map[string][]int{}, parseSuccess},
+		{RoundNearest, at(26, 23, 30), at(27, 0, 30), map[string][]int{"2020-10-26": {94, 95}, "2020-10-27": {0, 1}}, parseSuccess}}
+	t.Log("Test: importing time slices...")
+	for i, testCase := range testCases {
+		days, err := ImportTimeSlices([]ImportEntry{{"writing", testCase.start, testCase.end}}, testCase.rounding, activityID)
+		if (err != nil) != testCase.err {
+			t.Errorf("Test: import FAIL - outcome in test case %d", i+1)
+			continue
+		} else if err != nil {
+			t.Log("Test: success for import test case " + fmt.Sprint(i+1))
+			continue
+		}
+		matches := len(days) == len(testCase.slices)
+		for date, slices := range testCase.slices {
+			if len(days[date]) != len(slices) {
+				matches = false
+			}
+			for _, slice := range slices {
+				if days[date][slice] != "writing-id" {
+					matches = false
+				}
+			}
+		}
+		if !matches {
+			t.Errorf("Test: import FAIL - time slices in test case %d: %v", i+1, days)
+		} else {
+			t.Log("Test: success for import test case " + fmt.Sprint(i+1))
+		}
+	}
+	if lookups != len(testCases)-1 {
+		t.Errorf("Test: import FAIL - activity looked up %d times", lookups)
+	}
+	_, err := ImportTimeSlices([]ImportEntry{{"Gaming", at(26, 9, 0), at(26, 10, 0)}}, RoundNearest,
+		func(string) (string, error) { return "", errors.New("no config file") })
+	if err == nil {
+		t.Errorf("Test: import FAIL - activity error was ignored")
+	}
+}
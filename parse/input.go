@@ -0,0 +1,345 @@
+// Package parse has the parsing of the commands the user types into bt, and of the export
+// files from other time trackers bt imports. It has no dependencies on storage or the UI.
+package parse
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/seven-serverless-projects/bt/model"
+)
+
+/*
+Regular expression that can parse valid time entries from the user.
+
+t# references the time slices displayed in the UI by their numbered index.
+
+a# references activities displayed in the UI by their numbered index.
+
+One or more time slices, or a range of time slices, are associated with one activity.
+
+Valid Examples:
+t1 a1
+t1a1
+t3, t6 a2
+t3,t6 a2
+t3 t6 a2
+t3t6 a2
+t3t6a2
+t7-t10 a5
+t7-10 a5
+t7-t10a5
+t7-10a5
+
+Detailed breakdown of the regex string:
+
+^ - the start
+
+(?:t(?P<sliceIndex>[0-9]+),?\\s?)* - a single t# or a comma, space, or non-delimitted sequence of them
+
+| - or this other way of specifiying time
+
+t(?P<range1>[0-9]+)-t(?P<range2>[0-9]+) - a range t#=t#
+
+\s optional white space between time and activity
+
+a(?P<activity>[0-9]+) - activity in the form of a#
+
+$ - the end
+*/
+const timeEntryRegExString = "^((?:t(?P<sliceIndex>[0-9]+),?\\s?)*|t(?P<range1>[0-9]+)-t?(?P<range2>[0-9]+))\\s*a(?P<activity>[0-9]+)$"
+
+// Subset of the full parsing regex, with just a single t# or a comma, space, or non-delimitted sequence of them
+const timeSlicesRegExString = "^(t([0-9])+,?\\s?)+$"
+
+/*
+Regular expression that can parse valid time unassignments from the user.
+
+t# references the time slices displayed in the UI by their numbered index.
+
+One or more time slices, or a range of time slices, are associated with one activity.
+
+Valid Examples:
+u t1
+u t3, t6
+u t3,t6
+u t3 t6
+u t3t6
+ut3t6
+u t7-t10
+ut7-10
+
+Detailed breakdown of the regex string:
+
+^ - the start
+
+u\\s* - the literal letter u and optional white space
+
+(?:t(?P<sliceIndex>[0-9]+),?\\s?)* - a single t# or a comma, space, or non-delimitted sequence of them
+
+| - or this other way of specifiying time
+
+t(?P<range1>[0-9]+)-t(?P<range2>[0-9]+) - a range t#=t#
+
+$ - the end
+*/
+const unassignRegExString = "^u\\s*((?:t(?P<sliceIndex>[0-9]+),?\\s?)*|t(?P<range1>[0-9]+)-t?(?P<range2>[0-9]+))$"
+
+/*
+Regular expression that can parse a time of day to jump to from the user, by hour,
+or by hour and minute in 24h time.
+
+Valid Examples:
+goto 14:00
+goto 9
+g14
+g9:30
+*/
+const gotoRegExString = "^(?:goto\\s*|g)([0-9]{1,2})(?::([0-9]{2}))?$"
+
+/*
+Regular expression that can parse filling untracked time with an activity from the user, in
+the whole day, or in a span of the day from one time to another, by hour, or by hour and
+minute in 24h time.
+
+Valid Examples:
+fill a2
+fill 9:00-17:00 a4
+fill 9-17 a4
+fill 12:30 - 13:15 a1
+*/
+const fillRegExString = "^fill\\s+(?:([0-9:]+\\s*-\\s*[0-9:]+)\\s+)?a([0-9]+)$"
+
+// Starting a timer for an activity, e.g. start a2
+const startRegExString = "^start\\s*a([0-9]+)$"
+
+// Activities referenced by their number in the UI, e.g. a3 or 3
+const activityIndexRegExString = "^a?([0-9]+)$"
+
+var (
+	timeEntryRegExp     = regexp.MustCompile(timeEntryRegExString)
+	timeSlicesRegExp    = regexp.MustCompile(timeSlicesRegExString)
+	unassignRegExp      = regexp.MustCompile(unassignRegExString)
+	gotoRegExp          = regexp.MustCompile(gotoRegExString)
+	fillRegExp          = regexp.MustCompile(fillRegExString)
+	startRegExp         = regexp.MustCompile(startRegExString)
+	activityIndexRegExp = regexp.MustCompile(activityIndexRegExString)
+)
+
+// ParseUnassignment - parse the unassignment input from a user as integers.
+// Time entry unassignment disassociates a time slice, multiple time slices,
+// or a range of time slices from any assigned activity. The displayed count
+// is how many time slices the UI shows, the most a range can reach.
+func ParseUnassignment(entry string, displayed int) ([]int, [2]int, bool) {
+	timeSlices := []int{}
+	var timeRange [2]int
+	err := false
+	matches := unassignRegExp.FindStringSubmatch(entry)
+	if len(matches) < 5 ||
+		!validRange(matches[3], matches[4], displayed) ||
+		(matches[1] == matches[3] && matches[3] == matches[4] && matches[4] == "") {
+		err = true // silly user!
+	} else if !validTimeSlices(parseTimeSlices(matches[1]), displayed) {
+		err = true // not a time slice the UI shows
+	} else {
+		timeSlices = parseTimeSlices(matches[1])
+		timeRange[0], _ = strconv.Atoi(matches[3])
+		timeRange[1], _ = strconv.Atoi(matches[4])
+	}
+	return timeSlices, timeRange, err
+}
+
+// ParseTimeEntry - parse the time entry input from a user as integers.
+// Time entry input associates a time slice, multiple time slices,
+// or a range of time slices with a single activity. The displayed count is
+// how many time slices the UI shows, and the activity count how many activities.
+func ParseTimeEntry(entry string, displayed int, activityCount int) ([]int, [2]int, int, bool) {
+	timeSlices := []int{}
+	var timeRange [2]int
+	var activity int
+	err := false
+	matches := timeEntryRegExp.FindStringSubmatch(entry)
+	if len(matches) < 6 ||
+		!validRange(matches[3], matches[4], displayed) ||
+		!validActivity(matches[5], activityCount) {
+		err = true // silly user!
+	} else if !validTimeSlices(parseTimeSlices(matches[1]), displayed) {
+		err = true // not a time slice the UI shows
+	} else {
+		timeSlices = parseTimeSlices(matches[1])
+		timeRange[0], _ = strconv.Atoi(matches[3])
+		timeRange[1], _ = strconv.Atoi(matches[4])
+		activity, _ = strconv.Atoi(matches[5])
+	}
+	return timeSlices, timeRange, activity, err
+}
+
+// If the time portion of the user entry was provided as a time slice
+// or as a sequence of time slices, than parse the integers of each
+// provided time slice
+func parseTimeSlices(entry string) []int {
+	timeSlices := []int{}
+	// sanity check to make sure we are dealing with a set of time slices
+	if timeSlicesRegExp.MatchString(entry) {
+		// Strip out white space and comma delimiters
+		delimit := regexp.MustCompile("\\s*,*")
+		compactEntry := delimit.ReplaceAllLiteralString(entry, "")
+		// Slit on the t's
+		t := regexp.MustCompile("t")
+		timeSliceStrings := t.Split(compactEntry, -1)
+		for i := 1; i < len(timeSliceStrings); i++ { // first item is always blank
+			timeSlice, _ := strconv.Atoi(timeSliceStrings[i])
+			timeSlices = append(timeSlices, timeSlice)
+		}
+	}
+	return timeSlices
+}
+
+// Return true if the strings represent the start and end of a valid range of time slices
+func validRange(startString string, endString string, displayed int) bool {
+	valid := true
+	if startString != "" {
+		start, _ := strconv.Atoi(startString)
+		end, _ := strconv.Atoi(endString)
+		if start <= 0 ||
+			end <= 0 ||
+			start >= end ||
+			end > displayed {
+			valid = false
+		}
+	}
+	return valid
+}
+
+// Return true if every time slice is one of those displayed, t1 to t<displayed>
+func validTimeSlices(timeSlices []int, displayed int) bool {
+	for _, timeSlice := range timeSlices {
+		if timeSlice < 1 || timeSlice > displayed {
+			return false
+		}
+	}
+	return true
+}
+
+// Return true if the string represents the index of a valid activity from the UI
+func validActivity(activity string, activityCount int) bool {
+	valid := true
+	index, _ := strconv.Atoi(activity) // safe due to the regexes
+	if index < 1 || index > activityCount {
+		valid = false
+	}
+	return valid
+}
+
+// ExpandRange - given a range specified by 2 positive ints, the second bigger than the first,
+// return an array of all the ints between them, inclusive of the start and end
+func ExpandRange(start int, end int) []int {
+	expanded := []int{}
+	for i := start; i <= end; i++ {
+		expanded = append(expanded, i)
+	}
+	return expanded
+}
+
+// ParseGoto - parse the time of day to jump to from the user, as the index of its time slice in the day
+func ParseGoto(entry string) (int, bool) {
+	matches := gotoRegExp.FindStringSubmatch(entry)
+	if matches == nil {
+		return 0, true
+	}
+	hour, _ := strconv.Atoi(matches[1])
+	minute, _ := strconv.Atoi(matches[2]) // 0 when there's no minute
+	if hour > 23 || minute > 59 {
+		return 0, true
+	}
+	return (hour * 4) + (minute / 15), false
+}
+
+// ParseFill - parse filling untracked time from a user as the span of the day to fill, the whole
+// day when no span is given, and the activity to fill it with. The span runs from the time slice
+// containing the first time to the one before the second, so 9:00-17:00 ends at 16:45-17:00.
+func ParseFill(entry string, activityCount int) (model.Span, int, bool) {
+	matches := fillRegExp.FindStringSubmatch(entry)
+	if matches == nil || !validActivity(matches[2], activityCount) {
+		return model.Span{}, 0, true
+	}
+	activity, _ := strconv.Atoi(matches[2])
+	if matches[1] == "" {
+		return model.WholeDay, activity, false
+	}
+	span, err := model.ParseSpan(matches[1])
+	if err != nil {
+		return model.Span{}, 0, true
+	}
+	return span, activity, false
+}
+
+// ParseStart - parse starting the timer from a user as the activity to time
+func ParseStart(entry string, activityCount int) (int, bool) {
+	matches := startRegExp.FindStringSubmatch(entry)
+	if matches == nil || !validActivity(matches[1], activityCount) {
+		return 0, true
+	}
+	activity, _ := strconv.Atoi(matches[1])
+	return activity, false
+}
+
+// SplitArgs - split a command into its arguments on white space, keeping quoted arguments together,
+// e.g. activity add "Day Job" fffbaa is split into: activity, add, Day Job, fffbaa
+func SplitArgs(command string) []string {
+	args := []string{}
+	var arg strings.Builder
+	inArg := false
+	var quote rune
+	for _, r := range command {
+		switch {
+		case quote != 0 && r == quote: // end of the quoted argument
+			quote = 0
+		case quote != 0:
+			arg.WriteRune(r)
+		case r == '"' || r == '\'':
+			quote = r
+			inArg = true
+		case r == ' ' || r == '\t':
+			if inArg {
+				args = append(args, arg.String())
+				arg.Reset()
+				inArg = false
+			}
+		default:
+			arg.WriteRune(r)
+			inArg = true
+		}
+	}
+	if inArg {
+		args = append(args, arg.String())
+	}
+	return args
+}
+
+// ActivityIndexFor - return the index in the activities of the activity the user referenced,
+// either by its a# in the UI, which counts only active activities, or by its name (which also
+// finds activities that aren't active)
+func ActivityIndexFor(reference string, activities []model.Activity) (int, error) {
+	if matches := activityIndexRegExp.FindStringSubmatch(strings.ToLower(reference)); matches != nil {
+		active := model.Profile{Activities: activities}.ActiveActivities()
+		if !validActivity(matches[1], len(active)) {
+			return -1, fmt.Errorf("there's no activity a%s", matches[1])
+		}
+		index, _ := strconv.Atoi(matches[1])
+		id := active[index-1].ID
+		for i, activity := range activities {
+			if activity.ID == id {
+				return i, nil
+			}
+		}
+	}
+	for i, activity := range activities {
+		if strings.EqualFold(strings.TrimSpace(activity.Name), strings.TrimSpace(reference)) {
+			return i, nil
+		}
+	}
+	return -1, fmt.Errorf("there's no activity named %q", reference)
+}
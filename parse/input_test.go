@@ -0,0 +1,302 @@
+package parse
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/seven-serverless-projects/bt/model"
+)
+
+const parseSuccess = false
+const parseFailure = true
+
+// The number of time slices the UI shows, the most a range can reach
+const displayed = 24
+
+// TestParseTimeEntry - test user intput of a time to activity entry
+func TestParseTimeEntry(t *testing.T) {
+
+	type testCase struct {
+		timeEntry  string
+		timeSlices []int
+		timeRange  [2]int
+		activity   int
+		err        bool
+	}
+
+	testCases := []testCase{
+		// failure cases - invalid time entries
+		{"t1", []int{}, [2]int{}, 0, parseFailure},
+		{"t1 a", []int{}, [2]int{}, 0, parseFailure},
+		{"t1a", []int{}, [2]int{}, 0, parseFailure},
+		{"t a1", []int{}, [2]int{}, 0, parseFailure},
+		{"ta1", []int{}, [2]int{}, 0, parseFailure},
+		{"ta", []int{}, [2]int{}, 0, parseFailure},
+		{"ta a1", []int{}, [2]int{}, 0, parseFailure},
+		{"ta1 a1", []int{}, [2]int{}, 0, parseFailure},
+		{"t1 ab", []int{}, [2]int{}, 0, parseFailure},
+		{"t1 ab1", []int{}, [2]int{}, 0, parseFailure},
+		{"t1-t1 a1", []int{}, [2]int{}, 0, parseFailure},
+		{"t0-t1 a1", []int{}, [2]int{}, 0, parseFailure},
+		{"t2-t1 a1", []int{}, [2]int{}, 0, parseFailure},
+		{"t1-t" + fmt.Sprint(displayed+1) + " a1", []int{}, [2]int{}, 0, parseFailure},
+		{"t0 a1", []int{}, [2]int{}, 0, parseFailure},
+		{"t25 a1", []int{}, [2]int{}, 0, parseFailure},
+		{"t3, t" + fmt.Sprint(displayed+1) + " a1", []int{}, [2]int{}, 0, parseFailure},
+		// success cases - valid time entries
+		{"t1 a1", []int{1}, [2]int{}, 1, parseSuccess},
+		{"t1a1", []int{1}, [2]int{}, 1, parseSuccess},
+		{"t3, t6 a2", []int{3, 6}, [2]int{}, 2, parseSuccess},
+		{"t3,t6 a2", []int{3, 6}, [2]int{}, 2, parseSuccess},
+		{"t3 t6 a2", []int{3, 6}, [2]int{}, 2, parseSuccess},
+		{"t3t6 a2", []int{3, 6}, [2]int{}, 2, parseSuccess},
+		{"t3t6a2", []int{3, 6}, [2]int{}, 2, parseSuccess},
+		{"t7-t10 a5", []int{}, [2]int{7, 10}, 5, parseSuccess},
+		{"t7-10 a5", []int{}, [2]int{7, 10}, 5, parseSuccess},
+		{"t7-t10a5", []int{}, [2]int{7, 10}, 5, parseSuccess},
+		{"t7-10a5", []int{}, [2]int{7, 10}, 5, parseSuccess}}
+	t.Log("Test: parsing user time entries...")
+	for i, testCase := range testCases {
+		timeSlices, timeRange, activity, err := ParseTimeEntry(testCase.timeEntry, displayed, 5)
+		if !err == testCase.err {
+			t.Errorf("Test: parse entry FAIL -  parse outcome")
+		} else if !reflect.DeepEqual(timeSlices, testCase.timeSlices) {
+			t.Errorf("Test: parse entry FAIL - time slices in test case %d", i+1)
+		} else if timeRange != testCase.timeRange {
+			t.Errorf("Test: parse entry FAIL - time range in test case %d", i+1)
+		} else if activity != testCase.activity {
+			t.Errorf("Test: parse entry FAIL - activity in test case %d", i+1)
+		} else {
+			t.Log("Test: success for entry test case " + fmt.Sprint(i+1))
+		}
+	}
+}
+
+func TestParseUnassignment(t *testing.T) {
+
+	type testCase struct {
+		timeUnassignment string
+		timeSlices       []int
+		timeRange        [2]int
+		err              bool
+	}
+
+	testCases := []testCase{
+		// failure cases
+		{"u", []int{}, [2]int{}, parseFailure},
+		{"u t", []int{}, [2]int{}, parseFailure},
+		{"u ta", []int{}, [2]int{}, parseFailure},
+		{"u ta1", []int{}, [2]int{}, parseFailure},
+		{"ua1", []int{}, [2]int{}, parseFailure},
+		{"uta", []int{}, [2]int{}, parseFailure},
+		{"u t1-t1", []int{}, [2]int{}, parseFailure},
+		{"u t0-t1", []int{}, [2]int{}, parseFailure},
+		{"u t2-t1", []int{}, [2]int{}, parseFailure},
+		{"u t1-t" + fmt.Sprint(displayed+1), []int{}, [2]int{}, parseFailure},
+		{"u t0", []int{}, [2]int{}, parseFailure},
+		{"u t25", []int{}, [2]int{}, parseFailure},
+		{"u t3 t" + fmt.Sprint(displayed+1), []int{}, [2]int{}, parseFailure},
+		// success cases
+		{"u t1", []int{1}, [2]int{}, parseSuccess},
+		{"ut1", []int{1}, [2]int{}, parseSuccess},
+		{"u t3, t6", []int{3, 6}, [2]int{}, parseSuccess},
+		{"ut3, t6", []int{3, 6}, [2]int{}, parseSuccess},
+		{"u t3,t6", []int{3, 6}, [2]int{}, parseSuccess},
+		{"ut3,t6", []int{3, 6}, [2]int{}, parseSuccess},
+		{"u t3 t6", []int{3, 6}, [2]int{}, parseSuccess},
+		{"ut3 t6", []int{3, 6}, [2]int{}, parseSuccess},
+		{"u t3t6", []int{3, 6}, [2]int{}, parseSuccess},
+		{"ut3t6", []int{3, 6}, [2]int{}, parseSuccess},
+		{"u t7-t10", []int{}, [2]int{7, 10}, parseSuccess},
+		{"ut7-t10", []int{}, [2]int{7, 10}, parseSuccess},
+		{"u t7-10", []int{}, [2]int{7, 10}, parseSuccess},
+		{"ut7-10", []int{}, [2]int{7, 10}, parseSuccess}}
+	t.Log("Test: parsing user time unassignment...")
+	for i, testCase := range testCases {
+		timeSlices, timeRange, err := ParseUnassignment(testCase.timeUnassignment, displayed)
+		if !err == testCase.err {
+			t.Errorf("Test: parse unassignment FAIL -  parse outcome")
+		} else if !reflect.DeepEqual(timeSlices, testCase.timeSlices) {
+			t.Errorf("Test: parse unassignment FAIL - time slices in test case %d", i+1)
+		} else if timeRange != testCase.timeRange {
+			t.Errorf("Test: parse unassignment FAIL - time range in test case %d", i+1)
+		} else {
+			t.Log("Test: success for unassignment test case " + fmt.Sprint(i+1))
+		}
+	}
+}
+
+// TestSplitArgs - test splitting commands into arguments, keeping quoted arguments together
+func TestSplitArgs(t *testing.T) {
+
+	type testCase struct {
+		command string
+		args    []string
+	}
+
+	testCases := []testCase{
+		{"", []string{}},
+		{"activity hide 3", []string{"activity", "hide", "3"}},
+		{"  activity   hide\t3 ", []string{"activity", "hide", "3"}},
+		{`activity add "Day Job" #fffbaa`, []string{"activity", "add", "Day Job", "#fffbaa"}},
+		{`activity rename a2 'Board "Games"'`, []string{"activity", "rename", "a2", `Board "Games"`}},
+		{`activity add ""`, []string{"activity", "add", ""}},
+		{`activity add "Unfinished`, []string{"activity", "add", "Unfinished"}}}
+	t.Log("Test: splitting command arguments...")
+	for i, testCase := range testCases {
+		args := SplitArgs(testCase.command)
+		if !reflect.DeepEqual(args, testCase.args) {
+			t.Errorf("Test: split args FAIL - %q in test case %d", args, i+1)
+		} else {
+			t.Log("Test: success for split args test case " + fmt.Sprint(i+1))
+		}
+	}
+}
+
+// TestActivityIndexFor - test finding activities by their a# or name
+func TestActivityIndexFor(t *testing.T) {
+	activities := []model.Activity{
+		{ID: "1", Name: "Sleeping", Color: "08b4ff", Active: true},
+		{ID: "2", Name: "Gaming", Color: "ffc885", Active: false},
+		{ID: "3", Name: "Day Job", Color: "fffbaa", Active: true}}
+
+	type testCase struct {
+		reference string
+		index     int
+	}
+
+	testCases := []testCase{
+		{"a1", 0},
+		{"1", 0},
+		{"a2", 2}, // a# skips inactive activities, as the UI does
+		{"gaming", 1},
+		{"Day Job", 2},
+		{"a3", -1},
+		{"a0", -1},
+		{"Reading", -1}}
+	t.Log("Test: finding activities...")
+	for i, testCase := range testCases {
+		index, err := ActivityIndexFor(testCase.reference, activities)
+		if index != testCase.index || (err != nil) != (testCase.index == -1) {
+			t.Errorf("Test: activity index FAIL - %d in test case %d", index, i+1)
+		} else {
+			t.Log("Test: success for activity index test case " + fmt.Sprint(i+1))
+		}
+	}
+}
+
+// TestParseGoto - test user input of a time of day to jump to
+func TestParseGoto(t *testing.T) {
+
+	type testCase struct {
+		entry string
+		slice int
+		err   bool
+	}
+
+	testCases := []testCase{
+		// failure cases
+		{"g", 0, parseFailure},
+		{"goto", 0, parseFailure},
+		{"g24", 0, parseFailure},
+		{"g9:60", 0, parseFailure},
+		{"g9:5", 0, parseFailure},
+		{"g123", 0, parseFailure},
+		{"go 9", 0, parseFailure},
+		// success cases
+		{"g0", 0, parseSuccess},
+		{"g14", 56, parseSuccess},
+		{"goto 14:00", 56, parseSuccess},
+		{"goto14", 56, parseSuccess},
+		{"g9:30", 38, parseSuccess},
+		{"g9:44", 38, parseSuccess},
+		{"goto 23:45", 95, parseSuccess}}
+	t.Log("Test: parsing goto times...")
+	for i, testCase := range testCases {
+		slice, err := ParseGoto(testCase.entry)
+		if err != testCase.err {
+			t.Errorf("Test: parse goto FAIL - parse outcome in test case %d", i+1)
+		} else if slice != testCase.slice {
+			t.Errorf("Test: parse goto FAIL - time slice in test case %d", i+1)
+		} else {
+			t.Log("Test: success for goto test case " + fmt.Sprint(i+1))
+		}
+	}
+}
+
+// TestParseFill - test parsing filling untracked time, in the whole day or a span of it
+func TestParseFill(t *testing.T) {
+
+	type testCase struct {
+		entry    string
+		span     model.Span
+		activity int
+		err      bool
+	}
+
+	testCases := []testCase{
+		// failure cases
+		{"fill", model.Span{}, 0, parseFailure},
+		{"fill a", model.Span{}, 0, parseFailure},
+		{"fill a0", model.Span{}, 0, parseFailure},
+		{"fill a6", model.Span{}, 0, parseFailure},
+		{"fill 9:00 a2", model.Span{}, 0, parseFailure},
+		{"fill 17:00-9:00 a2", model.Span{}, 0, parseFailure},
+		{"fill 9:00-9:10 a2", model.Span{}, 0, parseFailure}, // no whole time slice
+		{"fill 9:00-25:00 a2", model.Span{}, 0, parseFailure},
+		{"fill 9:00-24:15 a2", model.Span{}, 0, parseFailure},
+		{"fill 9:60-10 a2", model.Span{}, 0, parseFailure},
+		{"fill 9:00-17:00a2", model.Span{}, 0, parseFailure},
+		{"filla2", model.Span{}, 0, parseFailure},
+		// success cases
+		{"fill a2", model.WholeDay, 2, parseSuccess},
+		{"fill   a5", model.WholeDay, 5, parseSuccess},
+		{"fill 9:00-17:00 a4", model.Span{Start: 36, End: 68}, 4, parseSuccess},
+		{"fill 9-17 a4", model.Span{Start: 36, End: 68}, 4, parseSuccess},
+		{"fill 12:30 - 13:15 a1", model.Span{Start: 50, End: 53}, 1, parseSuccess},
+		{"fill 0:00-24:00 a1", model.WholeDay, 1, parseSuccess},
+		{"fill 9:10-9:20 a1", model.Span{Start: 36, End: 37}, 1, parseSuccess}}
+	t.Log("Test: parsing fill...")
+	for i, testCase := range testCases {
+		span, activity, err := ParseFill(testCase.entry, 5)
+		if err != testCase.err {
+			t.Errorf("Test: parse fill FAIL - parse outcome in test case %d", i+1)
+		} else if span != testCase.span || activity != testCase.activity {
+			t.Errorf("Test: parse fill FAIL - span %v activity %d in test case %d", span, activity, i+1)
+		} else {
+			t.Log("Test: success for fill test case " + fmt.Sprint(i+1))
+		}
+	}
+}
+
+// TestParseStart - test parsing starting the timer for an activity
+func TestParseStart(t *testing.T) {
+
+	type testCase struct {
+		entry    string
+		activity int
+		err      bool
+	}
+
+	testCases := []testCase{
+		// failure cases
+		{"start", 0, parseFailure},
+		{"start a0", 0, parseFailure},
+		{"start a6", 0, parseFailure},
+		{"start 2", 0, parseFailure},
+		{"start a2 a3", 0, parseFailure},
+		// success cases
+		{"start a2", 2, parseSuccess},
+		{"starta5", 5, parseSuccess},
+		{"start   a1", 1, parseSuccess}}
+	t.Log("Test: parsing start...")
+	for i, testCase := range testCases {
+		activity, err := ParseStart(testCase.entry, 5)
+		if err != testCase.err || activity != testCase.activity {
+			t.Errorf("Test: parse start FAIL - activity %d in test case %d", activity, i+1)
+		} else {
+			t.Log("Test: success for start test case " + fmt.Sprint(i+1))
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/user"
+	"path/filepath"
+
+	"github.com/seven-serverless-projects/bt/model"
+)
+
+// ReadConfig - read and parse the specified configuration file
+func ReadConfig(configFile string) (model.Config, error) {
+	conf := model.Config{}
+	// Read the config file
+	fileContents, err := ioutil.ReadFile(configFile)
+	if err != nil {
+		return conf, fmt.Errorf("unable to read the config file at %s: %w", configFile, err)
+	}
+	// Parse the config file JSON
+	err = json.Unmarshal([]byte(fileContents), &conf)
+	if err != nil {
+		return conf, fmt.Errorf("unable to parse the config file at %s: %w", configFile, err)
+	}
+	return conf, nil
+}
+
+// WriteConfig - write the specified configuration as JSON to the specified file name. The file
+// is replaced atomically, so a crash part way through never leaves a truncated config.
+func WriteConfig(conf model.Config, configFile string) error {
+	fileContents, err := json.MarshalIndent(conf, "", " ")
+	if err != nil {
+		return fmt.Errorf("unable to serialize the config for %s: %w", configFile, err)
+	}
+	// Write through a symlink (e.g. to a dotfiles repo) rather than replacing it
+	if target, err := filepath.EvalSymlinks(configFile); err == nil {
+		configFile = target
+	}
+	if err := os.MkdirAll(filepath.Dir(configFile), 0755); err != nil {
+		return fmt.Errorf("unable to create the config directory for %s: %w", configFile, err)
+	}
+	tempFile, err := ioutil.TempFile(filepath.Dir(configFile), ".bt-config-*")
+	if err != nil {
+		return fmt.Errorf("unable to write the config file at %s: %w", configFile, err)
+	}
+	defer os.Remove(tempFile.Name()) // no-op once it's been renamed
+	_, err = tempFile.Write(fileContents)
+	if closeErr := tempFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tempFile.Name(), 0644)
+	}
+	if err == nil {
+		err = os.Rename(tempFile.Name(), configFile)
+	}
+	if err != nil {
+		return fmt.Errorf("unable to write the config file at %s: %w", configFile, err)
+	}
+	return nil
+}
+
+// SaveActivities - write the named profile's activities back to the config file it came from,
+// leaving the rest of the config file as it is. A blank name is the top level profile. If the
+// config file is missing, it's written from the profile's config.
+func SaveActivities(configFile string, name string, profileConf model.Config) error {
+	conf, err := ReadConfig(configFile)
+	if errors.Is(err, os.ErrNotExist) {
+		conf, err = profileConf, nil
+	}
+	if err != nil {
+		return err
+	}
+	if name == "" {
+		conf.Activities = profileConf.Activities
+	} else {
+		profile, ok := conf.Profiles[name]
+		if !ok {
+			return fmt.Errorf("the %s profile is no longer in the config file at %s", name, configFile)
+		}
+		profile.Activities = profileConf.Activities
+		conf.Profiles[name] = profile
+	}
+	return WriteConfig(conf, configFile)
+}
+
+// ConfigFilePath - return the path of the configuration file, in order of precedence: the
+// specified path (from the --config flag), the BT_CONFIG environment variable, or bt/config.json
+// in the XDG config directory. A legacy ~/.bt config file is migrated to the XDG config directory
+// the first time it's needed, and its path is returned too, blank when nothing was migrated.
+func ConfigFilePath(flagPath string) (string, string, error) {
+	if flagPath != "" {
+		return flagPath, "", nil
+	}
+	if envPath := os.Getenv("BT_CONFIG"); envPath != "" {
+		return envPath, "", nil
+	}
+	homeDir, err := homeDirPath()
+	if err != nil {
+		return "", "", err
+	}
+	configFile := filepath.Join(xdgDirPath("XDG_CONFIG_HOME", homeDir, ".config"), "bt", "config.json")
+	legacyFile := filepath.Join(homeDir, ".bt")
+	migrated, err := migrateConfig(legacyFile, configFile)
+	if !migrated {
+		legacyFile = ""
+	}
+	return configFile, legacyFile, err
+}
+
+// DataDirPath - return the path of the directory for bt's local data, bt in the XDG data directory
+func DataDirPath() (string, error) {
+	homeDir, err := homeDirPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(xdgDirPath("XDG_DATA_HOME", homeDir, ".local/share"), "bt"), nil
+}
+
+// Return the XDG base directory from the environment variable, or the
+// specified default relative to the user's home directory
+func xdgDirPath(envVar string, homeDir string, defaultDir string) string {
+	if dir := os.Getenv(envVar); filepath.IsAbs(dir) {
+		return dir // the spec says relative paths are invalid and should be ignored
+	}
+	return filepath.Join(homeDir, defaultDir)
+}
+
+// Return the current user's home directory
+func homeDirPath() (string, error) {
+	// Get the current user
+	usr, err := user.Current()
+	if err != nil {
+		return "", fmt.Errorf("unable to get the active user: %w", err)
+	}
+	return usr.HomeDir, nil
+}
+
+// Move the legacy config file to the new config file location, if there's a legacy config
+// file and nothing at the new location yet, and return true if it was moved. A legacy config
+// file that's a symlink, e.g. into a dotfiles repo, is moved as a link to the same file.
+func migrateConfig(legacyFile string, configFile string) (bool, error) {
+	if _, err := os.Lstat(configFile); !os.IsNotExist(err) {
+		return false, nil
+	}
+	if info, err := os.Stat(legacyFile); err != nil || info.IsDir() {
+		return false, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(configFile), 0755); err != nil {
+		return false, fmt.Errorf("unable to create the config directory for %s: %w", configFile, err)
+	}
+	if info, err := os.Lstat(legacyFile); err == nil && info.Mode()&os.ModeSymlink != 0 {
+		if err := migrateConfigLink(legacyFile, configFile); err != nil {
+			return false, err
+		}
+	} else {
+		fileContents, err := ioutil.ReadFile(legacyFile)
+		if err != nil {
+			return false, fmt.Errorf("unable to read the config file at %s: %w", legacyFile, err)
+		}
+		if err := ioutil.WriteFile(configFile, fileContents, 0644); err != nil {
+			return false, fmt.Errorf("unable to write the config file at %s: %w", configFile, err)
+		}
+	}
+	if err := os.Remove(legacyFile); err != nil {
+		return false, fmt.Errorf("unable to remove the migrated config file at %s: %w", legacyFile, err)
+	}
+	return true, nil
+}
+
+// Link the new config file location to the file the legacy config file links to. A relative
+// link is relative to the legacy file's directory, so it's made absolute.
+func migrateConfigLink(legacyFile string, configFile string) error {
+	target, err := os.Readlink(legacyFile)
+	if err != nil {
+		return fmt.Errorf("unable to read the config file link at %s: %w", legacyFile, err)
+	}
+	if !filepath.IsAbs(target) {
+		target = filepath.Join(filepath.Dir(legacyFile), target)
+	}
+	if err := os.Symlink(target, configFile); err != nil {
+		return fmt.Errorf("unable to link the config file at %s to %s: %w", configFile, target, err)
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package storage
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/seven-serverless-projects/bt/model"
+)
+
+// TestConfigFilePath - test the precedence of config file locations and the legacy migration
+func TestConfigFilePath(t *testing.T) {
+	t.Log("Test: config file path...")
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("BT_CONFIG", "")
+
+	configFile, migratedFrom, err := ConfigFilePath("")
+	if err != nil || configFile != filepath.Join(dir, "bt", "config.json") || migratedFrom != "" {
+		t.Errorf("Test: config file path FAIL - XDG path %s %q %v", configFile, migratedFrom, err)
+	}
+	t.Setenv("BT_CONFIG", "/tmp/env.json")
+	if configFile, _, _ = ConfigFilePath(""); configFile != "/tmp/env.json" {
+		t.Errorf("Test: config file path FAIL - BT_CONFIG path %s", configFile)
+	}
+	if configFile, _, _ = ConfigFilePath("/tmp/flag.json"); configFile != "/tmp/flag.json" {
+		t.Errorf("Test: config file path FAIL - --config path %s", configFile)
+	}
+
+	legacyFile := filepath.Join(dir, ".bt")
+	newFile := filepath.Join(dir, "bt", "config.json")
+	ioutil.WriteFile(legacyFile, []byte(`{"name": "Albert"}`), 0644)
+	if migrated, err := migrateConfig(legacyFile, newFile); !migrated || err != nil {
+		t.Errorf("Test: config file path FAIL - migration %v", err)
+	}
+	if _, err := os.Stat(legacyFile); !os.IsNotExist(err) {
+		t.Errorf("Test: config file path FAIL - legacy file not removed")
+	}
+	if conf, err := ReadConfig(newFile); err != nil || conf.Name != "Albert" {
+		t.Errorf("Test: config file path FAIL - migrated file %v %v", conf, err)
+	}
+	ioutil.WriteFile(legacyFile, []byte(`{"name": "Sean"}`), 0644)
+	migrateConfig(legacyFile, newFile)
+	if conf, _ := ReadConfig(newFile); conf.Name != "Albert" {
+		t.Errorf("Test: config file path FAIL - migration overwrote the new file")
+	}
+
+	// A legacy config file linked into a dotfiles repo stays linked there
+	linkDir := t.TempDir()
+	dotfile := filepath.Join(linkDir, "dotfiles", "bt.json")
+	os.MkdirAll(filepath.Dir(dotfile), 0755)
+	ioutil.WriteFile(dotfile, []byte(`{"name": "Grace"}`), 0644)
+	legacyLink := filepath.Join(linkDir, ".bt")
+	linkedFile := filepath.Join(linkDir, "bt", "config.json")
+	os.Symlink(filepath.Join("dotfiles", "bt.json"), legacyLink)
+	if migrated, err := migrateConfig(legacyLink, linkedFile); !migrated || err != nil {
+		t.Errorf("Test: config file path FAIL - link migration %v", err)
+	}
+	if target, err := os.Readlink(linkedFile); err != nil || target != dotfile {
+		t.Errorf("Test: config file path FAIL - migrated link to %q %v", target, err)
+	}
+	if _, err := os.Lstat(legacyLink); !os.IsNotExist(err) {
+		t.Errorf("Test: config file path FAIL - legacy link not removed")
+	}
+	if conf, err := ReadConfig(linkedFile); err != nil || conf.Name != "Grace" {
+		t.Errorf("Test: config file path FAIL - linked file %v %v", conf, err)
+	}
+}
+
+// TestSaveActivities - test saving a profile's activities to the config file
+func TestSaveActivities(t *testing.T) {
+	t.Log("Test: saving activities...")
+	sleeping := model.Activity{ID: "1", Name: "Sleeping", Color: "08b4ff", Active: true}
+	meetings := model.Activity{ID: "2", Name: "Meetings", Color: "fffbaa", Active: true}
+	email := model.Activity{ID: "3", Name: "Email", Color: "ff9c9c", Active: true}
+	conf := model.Config{
+		Profile:        model.Profile{Name: "Albert", Activities: []model.Activity{sleeping}},
+		DefaultProfile: "work",
+		Profiles: map[string]model.Profile{
+			"work": {ProjectID: "work-project", Activities: []model.Activity{meetings}},
+		},
+	}
+	configFile := filepath.Join(t.TempDir(), "config.json")
+
+	top := model.ProfileConfig(conf, "")
+	top.Activities = append(top.Activities, email)
+	if err := SaveActivities(configFile, "", top); err != nil {
+		t.Errorf("Test: save activities FAIL - missing file %v", err)
+	}
+	if saved, err := ReadConfig(configFile); err != nil || len(saved.Activities) != 2 {
+		t.Errorf("Test: save activities FAIL - written from the profile %v %v", saved, err)
+	}
+
+	WriteConfig(conf, configFile)
+	work := model.ProfileConfig(conf, "work")
+	work.Activities = append(work.Activities, email)
+	if err := SaveActivities(configFile, "work", work); err != nil {
+		t.Errorf("Test: save activities FAIL - work profile %v", err)
+	}
+	saved, _ := ReadConfig(configFile)
+	if len(saved.Activities) != 1 || len(saved.Profiles["work"].Activities) != 2 ||
+		saved.Profiles["work"].ProjectID != "work-project" || saved.DefaultProfile != "work" {
+		t.Errorf("Test: save activities FAIL - saved config %v", saved)
+	}
+	if err := SaveActivities(configFile, "play", work); err == nil {
+		t.Errorf("Test: save activities FAIL - missing profile saved")
+	}
+}
@@ -0,0 +1,259 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	firebase "firebase.google.com/go" // https://godoc.org/firebase.google.com/go
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/seven-serverless-projects/bt/model"
+)
+
+// FirestoreStore - a user's days of time slices, stored in Firestore as a document per day
+type FirestoreStore struct {
+	app    *firebase.App
+	ctx    context.Context
+	client *firestore.Client
+	userID string
+}
+
+// NewFirestoreStore - return a store for the user's days in the Firestore of the Firebase project,
+// connected and ready to use
+func NewFirestoreStore(ctx context.Context, projectID string, userID string) (*FirestoreStore, error) {
+	conf := &firebase.Config{ProjectID: projectID}
+	app, err := firebase.NewApp(ctx, conf)
+	if err != nil {
+		return nil, fmt.Errorf("unable to initialize Firebase: %w", err)
+	}
+	client, err := app.Firestore(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to Firestore: %w", err)
+	}
+	return &FirestoreStore{app, ctx, client, userID}, nil
+}
+
+// Return the collection of the user's days
+func (store *FirestoreStore) days() *firestore.CollectionRef {
+	return store.client.
+		Collection("users").
+		Doc(store.userID).
+		Collection("days")
+}
+
+// LoadDay - return an initialized day for the specified date.
+// Load the document from Firestore for the specified day.
+// Include any stored timeslice activities for the day in the data.
+func (store *FirestoreStore) LoadDay(forDay time.Time) (model.Day, error) {
+	day := model.NewDay(forDay)
+	// Load the document for user and day, if it exists
+	var doc *firestore.DocumentSnapshot
+	err := retry(func() error {
+		var err error
+		doc, err = store.days().Doc(day.Date).Get(store.ctx)
+		return err
+	})
+	if (err != nil && status.Code(err) != codes.NotFound) || doc == nil {
+		// Error other than the document not existing
+		return day, fmt.Errorf("unable to read data for %s: %w", day.Date, err)
+	}
+	return dayFromData(forDay, doc), nil
+}
+
+// LoadDays - return the stored days for the calendar days from the date of the first time
+// to the date of the last, reading their documents from Firestore in one request
+func (store *FirestoreStore) LoadDays(first time.Time, last time.Time) ([]model.Day, error) {
+	dates := model.Dates(first, last)
+	docRefs := []*firestore.DocumentRef{}
+	for _, date := range dates {
+		docRefs = append(docRefs, store.days().Doc(date.Format(model.DateFormat)))
+	}
+	var docs []*firestore.DocumentSnapshot
+	err := retry(func() error {
+		var err error
+		docs, err = store.client.GetAll(store.ctx, docRefs)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to read data for %s to %s: %w", first.Format(model.DateFormat), last.Format(model.DateFormat), err)
+	}
+	days := []model.Day{}
+	for i, doc := range docs {
+		days = append(days, dayFromData(dates[i], doc))
+	}
+	return days, nil
+}
+
+// Return the day for the date of the specified time, with the time slice data of its document,
+// which has no data if the day isn't stored
+func dayFromData(forDay time.Time, doc *firestore.DocumentSnapshot) model.Day {
+	day := model.NewDay(forDay)
+	day.Stored = doc.Exists()
+	timeSliceMap := doc.Data()
+	// for each time slice in the day check if there's a matching loaded time slice
+	for i, slice := range day.TimeSlices {
+		loadedData := timeSliceMap[fmt.Sprint(i)]
+		if loadedData != nil { // the loaded time slice map is sparse
+			activityID := loadedData.(map[string]interface{})["activity_id"]
+			if activityID != nil {
+				slice.ActivityID = activityID.(string) // Type conversion
+			}
+			note := loadedData.(map[string]interface{})["note"]
+			if note != nil {
+				slice.Note = note.(string) // Type conversion
+			}
+			slice.Auto = loadedData.(map[string]interface{})["auto"] == "true"
+		}
+		day.TimeSlices[i] = slice
+	}
+	return day
+}
+
+// SaveDay - persist the timeslices for the specified day
+func (store *FirestoreStore) SaveDay(day model.Day) error {
+	// Save the document for user and day, if it exists
+	err := retry(func() error {
+		_, err := store.days().
+			Doc(day.Date).
+			Set(store.ctx, sparseTimeSliceActivityMap(day.TimeSlices[:]))
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("unable to save data for %s: %w", day.Date, err)
+	}
+	return nil
+}
+
+// DaysWithActivities - return the number of the user's stored days that have time slices
+// assigned to each of the specified activities, or to every activity if they're nil. Safe to
+// call outside of the UI's event loop.
+func (store *FirestoreStore) DaysWithActivities(activityIDs []string) (map[string]int, error) {
+	dayCounts := make(map[string]int)
+	docs := store.days().Documents(store.ctx)
+	defer docs.Stop()
+	for {
+		doc, err := docs.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return dayCounts, fmt.Errorf("unable to read the stored days: %w", err)
+		}
+		// Count each activity once per day
+		onDay := make(map[string]bool)
+		for _, loadedData := range doc.Data() {
+			if slice, ok := loadedData.(map[string]interface{}); ok {
+				if activityID, ok := slice["activity_id"].(string); ok {
+					onDay[activityID] = true
+				}
+			}
+		}
+		for activityID := range onDay {
+			if activityID != "" && (activityIDs == nil || containsID(activityIDs, activityID)) {
+				dayCounts[activityID]++
+			}
+		}
+	}
+	return dayCounts, nil
+}
+
+// Return the document of the user's running timer, shared by every machine the user runs bt on
+func (store *FirestoreStore) timerDoc() *firestore.DocumentRef {
+	return store.client.
+		Collection("users").
+		Doc(store.userID).
+		Collection("state").
+		Doc("timer")
+}
+
+// LoadTimer - return the user's timer from Firestore, one that isn't running if there's no document
+func (store *FirestoreStore) LoadTimer() (model.Timer, error) {
+	var timer model.Timer
+	var doc *firestore.DocumentSnapshot
+	err := retry(func() error {
+		var err error
+		doc, err = store.timerDoc().Get(store.ctx)
+		return err
+	})
+	if status.Code(err) == codes.NotFound {
+		return timer, nil
+	}
+	if err != nil {
+		return timer, fmt.Errorf("unable to read the timer: %w", err)
+	}
+	data := doc.Data()
+	timer.ActivityID, _ = data["activity_id"].(string)
+	timer.Start, _ = data["start"].(time.Time)
+	timer.Through, _ = data["through"].(time.Time)
+	return timer, nil
+}
+
+// SaveTimer - persist the user's timer, deleting its document when it isn't running
+func (store *FirestoreStore) SaveTimer(timer model.Timer) error {
+	err := retry(func() error {
+		if !timer.Running() {
+			_, err := store.timerDoc().Delete(store.ctx)
+			return err
+		}
+		_, err := store.timerDoc().Set(store.ctx, map[string]interface{}{
+			"activity_id": timer.ActivityID,
+			"start":       timer.Start,
+			"through":     timer.Through,
+		})
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("unable to save the timer: %w", err)
+	}
+	return nil
+}
+
+// Close - close the connection to Firestore
+func (store *FirestoreStore) Close() error {
+	return store.client.Close()
+}
+
+// Run the Firestore operation, retrying it with a short backoff if it fails
+// with an error that's likely to be transient
+func retry(operation func() error) error {
+	backoff := 250 * time.Millisecond
+	err := operation()
+	for attempt := 1; attempt < 3 && transient(err); attempt++ {
+		time.Sleep(backoff)
+		backoff *= 2
+		err = operation()
+	}
+	return err
+}
+
+// Return true if the error from Firestore is likely to succeed if retried
+func transient(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted:
+		return true
+	}
+	return false
+}
+
+// Given an array of all the timeslices for a day, create a map of just the timeslices
+// with an assigned activity ID or a note, using the timeslice index as the key. Time slices
+// assigned by a rule are marked as auto.
+func sparseTimeSliceActivityMap(timeSlices []model.TimeSlice) map[string]map[string]string {
+	timeSliceMap := make(map[string]map[string]string)
+	for i := range timeSlices {
+		if timeSlices[i].ActivityID != "" || timeSlices[i].Note != "" {
+			timeSliceMap[fmt.Sprint(i)] = map[string]string{"activity_id": timeSlices[i].ActivityID}
+			if timeSlices[i].Note != "" {
+				timeSliceMap[fmt.Sprint(i)]["note"] = timeSlices[i].Note
+			}
+			if timeSlices[i].Auto {
+				timeSliceMap[fmt.Sprint(i)]["auto"] = "true"
+			}
+		}
+	}
+	return timeSliceMap
+}
@@ -0,0 +1,70 @@
+package storage
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// HistoryFileName - the name of the file in the data directory the command history is kept in
+	HistoryFileName = "history"
+	// HistoryLimit - the most commands kept in the history
+	HistoryLimit = 500
+)
+
+// LoadHistory - return the commands in the history file, oldest first, and trim the file if
+// it's grown past the limit. A missing file is an empty history.
+func LoadHistory(file string) ([]string, error) {
+	history := []string{}
+	historyFile, err := os.Open(file)
+	if os.IsNotExist(err) {
+		return history, nil
+	} else if err != nil {
+		return history, fmt.Errorf("unable to read the history file %s: %w", file, err)
+	}
+	defer historyFile.Close()
+	scanner := bufio.NewScanner(historyFile)
+	for scanner.Scan() {
+		if command := strings.TrimSpace(scanner.Text()); command != "" {
+			history = append(history, command)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return history, fmt.Errorf("unable to read the history file %s: %w", file, err)
+	}
+	if len(history) > HistoryLimit {
+		history = history[len(history)-HistoryLimit:]
+		if err := ioutil.WriteFile(file, []byte(strings.Join(history, "\n")+"\n"), 0600); err != nil {
+			return history, fmt.Errorf("unable to trim the history file %s: %w", file, err)
+		}
+	}
+	return history, nil
+}
+
+// AddHistory - add the command to the end of the history, and of the history file, unless
+// it's blank or a repeat of the last command
+func AddHistory(history []string, command string, file string) ([]string, error) {
+	if command == "" || (len(history) > 0 && history[len(history)-1] == command) {
+		return history, nil
+	}
+	history = append(history, command)
+	if len(history) > HistoryLimit {
+		history = history[len(history)-HistoryLimit:]
+	}
+	if err := os.MkdirAll(filepath.Dir(file), 0700); err != nil {
+		return history, fmt.Errorf("unable to save the history: %w", err)
+	}
+	historyFile, err := os.OpenFile(file, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return history, fmt.Errorf("unable to save the history: %w", err)
+	}
+	defer historyFile.Close()
+	if _, err := historyFile.WriteString(command + "\n"); err != nil {
+		return history, fmt.Errorf("unable to save the history: %w", err)
+	}
+	return history, nil
+}
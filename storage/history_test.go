@@ -0,0 +1,45 @@
+package storage
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestHistory - test keeping the command history in the history file
+func TestHistory(t *testing.T) {
+	t.Log("Test: command history...")
+	file := filepath.Join(t.TempDir(), "bt", HistoryFileName)
+
+	history, err := LoadHistory(file)
+	if err != nil || len(history) != 0 {
+		t.Errorf("Test: history FAIL - missing file %v %v", history, err)
+	}
+	for _, command := range []string{"t1 a1", "", "t1 a1", "g9", "t1 a1"} {
+		if history, err = AddHistory(history, command, file); err != nil {
+			t.Errorf("Test: history FAIL - add %q %v", command, err)
+		}
+	}
+	expected := []string{"t1 a1", "g9", "t1 a1"}
+	if fmt.Sprint(history) != fmt.Sprint(expected) {
+		t.Errorf("Test: history FAIL - added %v", history)
+	}
+	if loaded, err := LoadHistory(file); err != nil || fmt.Sprint(loaded) != fmt.Sprint(expected) {
+		t.Errorf("Test: history FAIL - loaded %v %v", loaded, err)
+	}
+
+	lines := []string{}
+	for i := 0; i < HistoryLimit+10; i++ {
+		lines = append(lines, fmt.Sprintf("g%d", i))
+	}
+	ioutil.WriteFile(file, []byte(strings.Join(lines, "\n")), 0600)
+	history, err = LoadHistory(file)
+	if err != nil || len(history) != HistoryLimit || history[0] != "g10" {
+		t.Errorf("Test: history FAIL - limit %d %v", len(history), err)
+	}
+	if trimmed, _ := LoadHistory(file); len(trimmed) != HistoryLimit {
+		t.Errorf("Test: history FAIL - file not trimmed %d", len(trimmed))
+	}
+}
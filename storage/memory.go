@@ -0,0 +1,92 @@
+package storage
+
+import (
+	"sync"
+	"time"
+
+	"github.com/seven-serverless-projects/bt/model"
+)
+
+// MemoryStore - days of time slices kept in memory, for tests and tools that don't need them
+// to outlast the process. Safe to use from more than one goroutine.
+type MemoryStore struct {
+	mutex sync.Mutex
+	days  map[string]model.Day
+	timer model.Timer
+}
+
+// NewMemoryStore - return an empty store of days in memory
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{days: make(map[string]model.Day)}
+}
+
+// LoadDay - return the stored day for the date of the specified time
+func (store *MemoryStore) LoadDay(forDay time.Time) (model.Day, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	if day, ok := store.days[forDay.Format(model.DateFormat)]; ok {
+		return day, nil
+	}
+	return model.NewDay(forDay), nil
+}
+
+// SaveDay - store the day, replacing what was stored for its date
+func (store *MemoryStore) SaveDay(day model.Day) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	day.Stored = true
+	store.days[day.Date] = day
+	return nil
+}
+
+// LoadDays - return the stored days for the calendar days from the date of the first time
+// to the date of the last
+func (store *MemoryStore) LoadDays(first time.Time, last time.Time) ([]model.Day, error) {
+	days := []model.Day{}
+	for _, date := range model.Dates(first, last) {
+		day, _ := store.LoadDay(date)
+		days = append(days, day)
+	}
+	return days, nil
+}
+
+// DaysWithActivities - return the number of stored days that have time slices
+// assigned to each of the specified activities, or to every activity if they're nil
+func (store *MemoryStore) DaysWithActivities(activityIDs []string) (map[string]int, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	dayCounts := make(map[string]int)
+	for _, day := range store.days {
+		// Count each activity once per day
+		onDay := make(map[string]bool)
+		for _, slice := range day.TimeSlices {
+			onDay[slice.ActivityID] = true
+		}
+		for activityID := range onDay {
+			if activityID != "" && (activityIDs == nil || containsID(activityIDs, activityID)) {
+				dayCounts[activityID]++
+			}
+		}
+	}
+	return dayCounts, nil
+}
+
+// LoadTimer - return the stored timer
+func (store *MemoryStore) LoadTimer() (model.Timer, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	return store.timer, nil
+}
+
+// SaveTimer - store the timer, replacing the stored timer
+func (store *MemoryStore) SaveTimer(timer model.Timer) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	store.timer = timer
+	return nil
+}
+
+// Close - nothing to release for a store in memory
+func (store *MemoryStore) Close() error {
+	return nil
+}
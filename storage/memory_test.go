@@ -0,0 +1,50 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/seven-serverless-projects/bt/model"
+)
+
+// TestMemoryStore - test saving and loading days, and counting the days activities are on
+func TestMemoryStore(t *testing.T) {
+	t.Log("Test: memory store...")
+	store := NewMemoryStore()
+	monday := time.Date(2020, 10, 26, 9, 0, 0, 0, time.Local)
+
+	day, err := store.LoadDay(monday)
+	if err != nil || day.Date != "2020-10-26" || day.TimeSlices[36].Slice != 36 || day.TimeSlices[36].ActivityID != "" || day.Stored {
+		t.Errorf("Test: memory store FAIL - new day %v %v", day.Date, err)
+	}
+	day.TimeSlices[36].ActivityID = "1"
+	day.TimeSlices[37].ActivityID = "1"
+	store.SaveDay(day)
+	tuesday, _ := store.LoadDay(monday.AddDate(0, 0, 1))
+	tuesday.TimeSlices[0].ActivityID = "1"
+	tuesday.TimeSlices[1].ActivityID = "2"
+	store.SaveDay(tuesday)
+
+	if loaded, _ := store.LoadDay(monday.Add(time.Hour)); loaded.TimeSlices[37].ActivityID != "1" || !loaded.Stored {
+		t.Errorf("Test: memory store FAIL - saved day not loaded")
+	}
+	days, err := store.LoadDays(monday.AddDate(0, 0, -1), monday.AddDate(0, 0, 1))
+	if err != nil || len(days) != 3 || days[0].Date != "2020-10-25" || days[0].Stored || days[2].TimeSlices[1].ActivityID != "2" {
+		t.Errorf("Test: memory store FAIL - loaded days %d %v", len(days), err)
+	}
+	dayCounts, err := store.DaysWithActivities([]string{"1", "2", "3"})
+	if err != nil || dayCounts["1"] != 2 || dayCounts["2"] != 1 || dayCounts["3"] != 0 {
+		t.Errorf("Test: memory store FAIL - day counts %v %v", dayCounts, err)
+	}
+	if dayCounts, err := store.DaysWithActivities(nil); err != nil || len(dayCounts) != 2 || dayCounts["1"] != 2 {
+		t.Errorf("Test: memory store FAIL - day counts of every activity %v %v", dayCounts, err)
+	}
+
+	if timer, err := store.LoadTimer(); err != nil || timer.Running() {
+		t.Errorf("Test: memory store FAIL - new timer %v %v", timer, err)
+	}
+	store.SaveTimer(model.Timer{ActivityID: "1", Start: monday})
+	if timer, _ := store.LoadTimer(); timer.ActivityID != "1" || !timer.Start.Equal(monday) {
+		t.Errorf("Test: memory store FAIL - saved timer %v", timer)
+	}
+}
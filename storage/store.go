@@ -0,0 +1,40 @@
+// Package storage keeps BubbleTimer's data: the days of time slices in Firestore, and the
+// config and command history in local files.
+package storage
+
+import (
+	"time"
+
+	"github.com/seven-serverless-projects/bt/model"
+)
+
+// Store - where the user's days of time slices are kept
+type Store interface {
+	// LoadDay - return the stored day for the date of the specified time, with no time
+	// assigned if nothing's been stored for it
+	LoadDay(forDay time.Time) (model.Day, error)
+	// SaveDay - store the day, replacing what was stored for its date
+	SaveDay(day model.Day) error
+	// LoadDays - return the stored days for the calendar days from the date of the first
+	// time to the date of the last, oldest first, with no time assigned to any not stored
+	LoadDays(first time.Time, last time.Time) ([]model.Day, error)
+	// DaysWithActivities - return the number of stored days that have time slices assigned
+	// to each of the specified activities, or to every activity if they're nil
+	DaysWithActivities(activityIDs []string) (map[string]int, error)
+	// LoadTimer - return the stored timer, one that isn't running if none is stored
+	LoadTimer() (model.Timer, error)
+	// SaveTimer - store the timer, or remove the stored timer if it isn't running
+	SaveTimer(timer model.Timer) error
+	// Close - release the store's connection
+	Close() error
+}
+
+// Return true if the activity ID is one of the IDs
+func containsID(activityIDs []string, activityID string) bool {
+	for _, id := range activityIDs {
+		if id == activityID {
+			return true
+		}
+	}
+	return false
+}
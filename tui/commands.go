@@ -0,0 +1,149 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/seven-serverless-projects/bt/parse"
+)
+
+// Command - a command the user can type into the command input, how it's recognized and run,
+// and how it's described in the help
+type Command struct {
+	usage       string                              // how the command is typed, e.g. n, next
+	description string                              // what the command does
+	examples    []string                            // optional examples of the command
+	match       func(input string) bool             // true if the lowercased input is this command
+	run         func(rawInput string, input string) // do the command, given the raw and lowercased input
+}
+
+// Return the commands the user can type, in the order they're matched against the input
+func (ui *UI) initCommands() []Command {
+	return []Command{
+		{"?, help", "Show this help", nil, named("?", "help"),
+			func(string, string) { ui.showHelp() }},
+		{"q, quit", "Quit bt", nil, named("q", "quit"),
+			func(string, string) { ui.Stop() }},
+		{"+", "Show the next page of time slices, later in the day", nil, named("+"),
+			func(string, string) { ui.timeForward() }},
+		{"-", "Show the prior page of time slices, earlier in the day", nil, named("-"),
+			func(string, string) { ui.timeBackward() }},
+		{"n, next", "Show the next day", nil, named("n", "next"),
+			func(string, string) { ui.dayForward() }},
+		{"p, prior", "Show the prior day", nil, named("p", "prior"),
+			func(string, string) { ui.dayBackward() }},
+		{"t, today, r, refresh, reset", "Show today, up to the current time, reloading it", nil,
+			named("t", "today", "r", "refresh", "reset"),
+			func(string, string) { ui.dayTodayTimeNow() }},
+		{"y, yesterday", "Show yesterday", nil, named("y", "yesterday"),
+			func(string, string) { ui.dayYesterday() }},
+		{"day, whole day", "Switch between showing the whole day and the time slices that fit", nil,
+			named("day", "whole day"),
+			func(string, string) { ui.toggleWholeDay() }},
+		{"home", "Show the time slices from the start of the day", nil, named("home"),
+			func(string, string) { ui.jumpToTime(0) }},
+		{"end", "Show the time slices up to the end of the day", nil, named("end"),
+			func(string, string) { ui.jumpToTime(96) }},
+		{"goto H[:MM], gH[:MM]", "Show the time slices from a time of day, in 24h time",
+			[]string{"goto 14:00", "g9", "g9:30"},
+			func(input string) bool { _, err := parse.ParseGoto(input); return !err },
+			func(_ string, input string) {
+				slice, _ := parse.ParseGoto(input)
+				ui.jumpToTime(slice)
+			}},
+		{"gaps", "Show the untracked time in the day, up to the current time today", nil, named("gaps"),
+			func(string, string) { ui.showGaps() }},
+		{"fill [H[:MM]-H[:MM]] a#", "Assign an activity to the untracked time in the day so far, or from one time to another",
+			[]string{"fill a2", "fill 9:00-17:00 a4", "fill 12-13 a1"},
+			prefixed("fill "),
+			func(_ string, input string) {
+				span, activity, err := parse.ParseFill(input, len(ui.config.ActiveActivities()))
+				if !err {
+					ui.fillTime(span, activity)
+				}
+			}},
+		{"apply TEMPLATE [merge|overwrite]", "Assign the time of a day template from the config file, to the untracked time, or overwriting",
+			[]string{"apply workday", "apply workday overwrite"},
+			prefixed("apply "),
+			func(rawInput string, _ string) { ui.applyTemplate(parse.SplitArgs(rawInput)[1:]) }},
+		{"copy yesterday|YYYY-MM-DD [merge|overwrite]", "Assign the time of the day before, or another day, to the untracked time, or overwriting",
+			[]string{"copy yesterday", "copy 2020-10-26 overwrite"},
+			prefixed("copy "),
+			func(rawInput string, _ string) { ui.copyDay(parse.SplitArgs(rawInput)[1:]) }},
+		{"start a#", "Start timing an activity live, assigning each time slice to it as it's completed",
+			[]string{"start a2"},
+			prefixed("start"),
+			func(_ string, input string) {
+				activity, err := parse.ParseStart(input, len(ui.config.ActiveActivities()))
+				if !err {
+					ui.startTimer(activity)
+				}
+			}},
+		{"stop", "Stop the timer, assigning the time slice in progress if it's more than half done", nil, named("stop"),
+			func(string, string) { ui.stopTimer() }},
+		{"activity add|rename|color|hide|show", "Add or change an activity, saving it to the config file",
+			[]string{`activity add "Exercise" #33cc66`, `activity rename a3 "Board Games"`,
+				"activity color a3 ffc885", "activity hide a3", `activity show "Board Games"`},
+			prefixed("activity "),
+			func(rawInput string, _ string) { ui.activityCommand(parse.SplitArgs(rawInput)[1:]) }}, // activity names keep their case
+		{"profile [NAME]", "Switch to a profile from the config file, or back to the default",
+			[]string{"profile work", "profile"},
+			func(input string) bool { return input == "profile" || strings.HasPrefix(input, "profile ") },
+			func(rawInput string, _ string) { ui.switchProfile(strings.TrimSpace(rawInput[len("profile"):])) }},
+		{"t# a#", "Assign an activity to one or more time slices, or a range of them",
+			[]string{"t1 a1", "t3, t6 a2", "t3t6a2", "t7-t10 a5", "t7-10a5"},
+			prefixed("t"),
+			func(_ string, input string) {
+				timeSlices, timeRange, activity, err := parse.ParseTimeEntry(input, ui.viewport.Size, len(ui.config.ActiveActivities()))
+				if !err {
+					if timeRange[0] > 0 {
+						timeSlices = parse.ExpandRange(timeRange[0], timeRange[1])
+					}
+					ui.assignTime(timeSlices, activity)
+				}
+			}},
+		{"u t#", "Unassign the activity from one or more time slices, or a range of them",
+			[]string{"u t1", "u t3, t6", "ut3t6", "u t7-t10"},
+			prefixed("u"),
+			func(_ string, input string) {
+				timeSlices, timeRange, err := parse.ParseUnassignment(input, ui.viewport.Size)
+				if !err {
+					if timeRange[0] > 0 {
+						timeSlices = parse.ExpandRange(timeRange[0], timeRange[1])
+					}
+					ui.unassignTime(timeSlices)
+				}
+			}},
+	}
+}
+
+// Return a matcher for input that's exactly one of the names
+func named(names ...string) func(string) bool {
+	return func(input string) bool {
+		for _, name := range names {
+			if input == name {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Return a matcher for input that starts with the prefix
+func prefixed(prefix string) func(string) bool {
+	return func(input string) bool {
+		return strings.HasPrefix(input, prefix)
+	}
+}
+
+// Parse text input from the user, and do the requested action
+func (ui *UI) parseInput() {
+	rawInput := strings.TrimSpace(ui.commandInput.GetText())
+	input := strings.ToLower(rawInput)
+	for _, command := range ui.commands {
+		if command.match(input) {
+			command.run(rawInput, input)
+			break
+		}
+	}
+	ui.resetInput()
+}
@@ -0,0 +1,31 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestCommands - test that each command's examples in the help are parsed as that command
+func TestCommands(t *testing.T) {
+	t.Log("Test: command registry...")
+	ui := &UI{}
+	ui.commands = ui.initCommands()
+	for i, command := range ui.commands {
+		if command.usage == "" || command.description == "" {
+			t.Errorf("Test: commands FAIL - undocumented command %d", i+1)
+		}
+		for _, example := range command.examples {
+			for j, other := range ui.commands {
+				if other.match(strings.ToLower(example)) {
+					if j != i {
+						t.Errorf("Test: commands FAIL - example %q matched %q", example, other.usage)
+					}
+					break
+				}
+			}
+		}
+	}
+	if help := ui.helpText(); !strings.Contains(help, "goto 14:00") || !strings.Contains(help, "PgUp") {
+		t.Errorf("Test: commands FAIL - help text is missing commands or keys")
+	}
+}
@@ -0,0 +1,55 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rivo/tview" // https://github.com/rivo/tview
+
+	"github.com/seven-serverless-projects/bt/model"
+)
+
+const gapsPage = "gaps"
+
+// Show the runs of untracked time in the current day, up to the current time if it's today
+func (ui *UI) showGaps() {
+	gaps := ui.currentDay.Gaps(model.TrackableSpan(ui.currentDay, ui.clock.Now()))
+	if len(gaps) == 0 {
+		ui.showStatus("No untracked time")
+		return
+	}
+	lines := []string{}
+	untracked := 0
+	for _, gap := range gaps {
+		lines = append(lines, fmt.Sprintf("%s  %s", gap, model.SlicesText(gap.Slices())))
+		untracked += gap.Slices()
+	}
+	text := fmt.Sprintf("%s untracked\n\n%s", model.SlicesText(untracked), strings.Join(lines, "\n"))
+	gapsModal := tview.NewModal().
+		SetText(text).
+		AddButtons([]string{"OK"}).
+		SetDoneFunc(func(int, string) { ui.hideModal(gapsPage) })
+	ui.pages.AddPage(gapsPage, gapsModal, false, true)
+	ui.app.SetFocus(gapsModal)
+}
+
+// Assign the activity to the untracked time slices in the span of the current day, never
+// changing time that's already assigned, and persist the update. Filling the whole day
+// fills today only up to the current time.
+func (ui *UI) fillTime(span model.Span, activityIndex int) {
+	activity := ui.config.ActiveActivities()[activityIndex-1]
+	if span == model.WholeDay {
+		span = model.TrackableSpan(ui.currentDay, ui.clock.Now())
+	}
+	filled := ui.currentDay.Fill(span, activity.ID)
+	if filled == 0 {
+		ui.showStatus("No untracked time to fill")
+		return
+	}
+	ui.syncUI()
+	if err := ui.store.SaveDay(ui.currentDay); err != nil {
+		ui.showError(err)
+		return
+	}
+	ui.showStatus(fmt.Sprintf("Filled %s with %s", model.SlicesText(filled), activity.Name))
+}
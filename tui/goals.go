@@ -0,0 +1,81 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/seven-serverless-projects/bt/model"
+)
+
+// The number of cells in a goal's progress bar
+const progressBarCells = 10
+
+// The tview colors of progress bars, by how the time spent compares to the goal
+var goalColors = map[model.GoalState]string{
+	model.GoalBelow:    "gray",
+	model.GoalNear:     "yellow",
+	model.GoalMet:      "limegreen",
+	model.GoalExceeded: "red",
+}
+
+// Return true if any of the activities have a weekly goal
+func (ui *UI) hasWeeklyGoals() bool {
+	for _, activity := range ui.config.Activities {
+		for _, goal := range activity.Goals() {
+			if goal.Kind == model.WeeklyGoal {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Load the time spent on each activity on the other days of the current day's week, for the
+// progress towards weekly goals. Nothing is loaded if there are no weekly goals.
+func (ui *UI) loadWeek() error {
+	ui.weekSlices = nil
+	if !ui.hasWeeklyGoals() {
+		return nil
+	}
+	current, err := ui.currentDay.Time()
+	if err != nil {
+		return err
+	}
+	monday := model.WeekStart(current)
+	days, err := ui.store.LoadDays(monday, monday.AddDate(0, 0, 6))
+	if err != nil {
+		return err
+	}
+	otherDays := []model.Day{}
+	for _, day := range days {
+		if day.Date != ui.currentDay.Date {
+			otherDays = append(otherDays, day)
+		}
+	}
+	ui.weekSlices = model.ActivitySlices(otherDays...)
+	return nil
+}
+
+// Return the line shown under an activity for one of its goals: a progress bar colored by
+// how close the time spent is to the goal, and the goal
+func (ui *UI) goalText(activityID string, goal model.Goal) string {
+	slices := model.ActivitySlices(ui.currentDay)[activityID]
+	label := goal.String()
+	if goal.Kind == model.WeeklyGoal {
+		if ui.weekSlices == nil {
+			return "   " + dimText(label+" (the week isn't loaded)")
+		}
+		slices += ui.weekSlices[activityID]
+		label = model.SlicesText(slices) + ", " + label
+	}
+	return "   " + progressBar(slices, goal) + " " + dimText(label)
+}
+
+// Return a bar of the progress of the time slices towards the goal, in the goal's color
+func progressBar(slices int, goal model.Goal) string {
+	filled := slices * progressBarCells / goal.Slices
+	if filled > progressBarCells {
+		filled = progressBarCells
+	}
+	bar := strings.Repeat("▰", filled) + strings.Repeat("▱", progressBarCells-filled)
+	return "[" + goalColors[goal.State(slices)] + "]" + bar + "[-]"
+}
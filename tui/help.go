@@ -0,0 +1,86 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2" // https://github.com/gdamore/tcell
+	"github.com/rivo/tview"       // https://github.com/rivo/tview
+)
+
+const (
+	helpPage  = "help"
+	helpWidth = 100
+)
+
+// KeyBinding - a key, or mouse action, and what it does, as described in the help
+type KeyBinding struct {
+	keys        string
+	description string
+}
+
+// The key bindings of the command input, the time slices when they have the focus, and the mouse
+var keyBindings = []KeyBinding{
+	{"Up, Down", "Recall older or newer commands from the history"},
+	{"Shift-Up, Shift-Down", "Scroll the time slices by one"},
+	{"Ctrl-R", "Search the history for a command"},
+	{"Tab", "Complete the command, name or time, or move the focus to the time slices (when it's empty)"},
+	{"PgUp, PgDn", "Show the prior or next page of time slices"},
+	{"Home, End", "Show the start or end of the day (when the command is empty)"},
+	{"j, k", "Scroll the time slices by one (when the command is empty)"},
+	{"v", "Move the focus to the time slices and start selecting (when the command is empty)"},
+	{"1-9", "Assign activity a1-a9 to the selected time slices"},
+	{"?", "Show this help (when the command is empty)"},
+	{"Esc", "Clear the command and any selected time slices"},
+	{"Ctrl-C", "Quit bt"},
+	{"Time slices: j, k", "Move the cursor, extending the selection"},
+	{"Time slices: v", "Start or cancel selecting time slices from the cursor"},
+	{"Time slices: 1-9", "Assign activity a1-a9 to the selected time slices, or to the cursor's"},
+	{"Time slices: x", "Unassign the selected time slices, or the cursor's"},
+	{"Time slices: Esc", "Cancel selecting, or move the focus back to the command"},
+	{"Time slices: Tab, i", "Move the focus back to the command"},
+	{"Mouse: click, drag", "Select time slices"},
+	{"Mouse: click activity", "Assign the activity to the selected time slices"},
+	{"Mouse: right click", "Unassign the selected time slices, or add a note to them"},
+	{"Mouse: wheel", "Scroll the time slices by one"},
+}
+
+// Return the text of the help, from the commands that input is parsed with and the key bindings
+func (ui *UI) helpText() string {
+	helpText := "[limegreen]Commands[-]\n\n"
+	for _, command := range ui.commands {
+		helpText += fmt.Sprintf("  %-38s%s\n", tview.Escape(command.usage), command.description)
+		if len(command.examples) > 0 {
+			helpText += dimText(fmt.Sprintf("  %-38se.g. %s", "", strings.Join(command.examples, ", "))) + "\n"
+		}
+	}
+	helpText += "\n[limegreen]Keys[-]\n\n"
+	for _, binding := range keyBindings {
+		helpText += fmt.Sprintf("  %-38s%s\n", binding.keys, binding.description)
+	}
+	return helpText
+}
+
+// Show the help over the rest of the UI, until Esc, q, ? or Enter is pressed
+func (ui *UI) showHelp() {
+	help := tview.NewTextView().
+		SetDynamicColors(true).
+		SetText(ui.helpText())
+	help.SetBorder(true).
+		SetTitle(" Help — Esc to close ").
+		SetBorderPadding(1, 1, 1, 1).
+		SetBackgroundColor(bgColor)
+	help.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape || event.Key() == tcell.KeyEnter ||
+			event.Rune() == 'q' || event.Rune() == '?' {
+			ui.hideModal(helpPage)
+			return nil
+		}
+		return event // scroll the help
+	})
+	ui.pages.AddPage(helpPage, tview.NewGrid().
+		SetColumns(0, helpWidth, 0).
+		SetRows(1, 0, 1).
+		AddItem(help, 1, 1, 1, 1, 0, 0, true), true, true)
+	ui.app.SetFocus(help)
+}
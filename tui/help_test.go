@@ -0,0 +1,70 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+
+	"github.com/seven-serverless-projects/bt/storage"
+)
+
+// TestKeyHelp - test every key the command input handles is in the help, once
+func TestKeyHelp(t *testing.T) {
+	t.Log("Test: key help...")
+	test := startTestUI(t, time.Date(2020, 10, 26, 10, 5, 0, 0, time.Local), storage.NewMemoryStore())
+
+	documented := map[string]int{}
+	for _, binding := range keyBindings {
+		if strings.Contains(binding.keys, ": ") {
+			continue // the keys of the time slices and the mouse
+		}
+		for _, keys := range strings.Split(binding.keys, ", ") {
+			if len(keys) == 3 && keys[1] == '-' { // a range of keys, e.g. 1-9
+				for r := keys[0]; r <= keys[2]; r++ {
+					documented[string(r)]++
+				}
+			} else {
+				documented[keys]++
+			}
+		}
+	}
+	for keys, count := range documented {
+		if count > 1 {
+			t.Errorf("Test: key help FAIL - %s is in the help %d times", keys, count)
+		}
+	}
+
+	events := []*tcell.EventKey{}
+	for key := range tcell.KeyNames {
+		events = append(events, tcell.NewEventKey(key, 0, tcell.ModNone), tcell.NewEventKey(key, 0, tcell.ModShift))
+	}
+	for r := '!'; r <= '~'; r++ {
+		events = append(events, tcell.NewEventKey(tcell.KeyRune, r, tcell.ModNone))
+	}
+	for _, event := range events {
+		handled := false
+		test.ui.app.QueueUpdate(func() {
+			test.ui.selection = Selection{true, 40, 40} // so the number keys assign
+			handled = test.ui.navigationKeys(event) == nil
+			if test.ui.searching {
+				test.ui.endHistorySearch("")
+			}
+			test.ui.selection = Selection{}
+			test.ui.pages.RemovePage(helpPage)
+			test.ui.app.SetFocus(test.ui.commandInput)
+		})
+		name := tcell.KeyNames[event.Key()]
+		if event.Key() == tcell.KeyRune {
+			name = string(event.Rune())
+		}
+		shifted := event.Modifiers()&tcell.ModShift != 0
+		if handled && documented[name] == 0 && (!shifted || documented["Shift-"+name] == 0) {
+			if shifted {
+				name = "Shift-" + name
+			}
+			t.Errorf("Test: key help FAIL - %s is handled but isn't in the help", name)
+		}
+	}
+}
@@ -0,0 +1,238 @@
+package tui
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/gdamore/tcell/v2" // https://github.com/gdamore/tcell
+
+	"github.com/seven-serverless-projects/bt/model"
+	"github.com/seven-serverless-projects/bt/storage"
+)
+
+const (
+	commandLabel = "Command: "
+	searchLabel  = "History search: "
+)
+
+// Words and phrases that are whole commands, as opposed to e.g. t# or +, and can be completed
+var keywordRegExp = regexp.MustCompile("^[a-z][a-z ]+$")
+
+// The activity subcommands, completed after activity
+var activitySubcommands = []string{"add", "rename", "color", "hide", "show"}
+
+// Return the path of the file the command history is kept in
+func (ui *UI) historyFilePath() string {
+	return filepath.Join(ui.dataDir, storage.HistoryFileName)
+}
+
+// Add the command the user entered to the history, and stop browsing the history
+func (ui *UI) rememberCommand(command string) {
+	history, err := storage.AddHistory(ui.history, strings.TrimSpace(command), ui.historyFilePath())
+	ui.history = history
+	ui.historyIndex = len(ui.history)
+	if err != nil {
+		ui.showError(err)
+	}
+}
+
+// Replace the command input with an older (negative) or newer (positive) command from the
+// history. Moving past the newest command brings back what was being typed before.
+func (ui *UI) recallHistory(offset int) {
+	if ui.historyIndex == len(ui.history) {
+		ui.historyDraft = ui.commandInput.GetText()
+	}
+	index := ui.historyIndex + offset
+	if index < 0 || index > len(ui.history) {
+		return
+	}
+	ui.historyIndex = index
+	if index == len(ui.history) {
+		ui.commandInput.SetText(ui.historyDraft)
+	} else {
+		ui.commandInput.SetText(ui.history[index])
+	}
+}
+
+// Start searching the history, as the user types, for the newest command containing the
+// text, or when already searching, find the next older command containing it
+func (ui *UI) searchHistory() {
+	if ui.searching {
+		ui.findInHistory(ui.commandInput.GetText(), ui.searchMatch-1)
+		return
+	}
+	ui.searching = true
+	ui.searchMatch = -1
+	ui.historyDraft = ui.commandInput.GetText()
+	ui.commandInput.SetLabel(searchLabel).SetText("")
+	ui.showStatus("Type to search the history, Ctrl-R for older, Enter to run, Esc to cancel")
+}
+
+// Find the newest command in the history at or before the index, containing the text
+func (ui *UI) findInHistory(text string, from int) {
+	for i := from; i >= 0 && i < len(ui.history); i-- {
+		if strings.Contains(strings.ToLower(ui.history[i]), strings.ToLower(text)) {
+			ui.searchMatch = i
+			ui.showStatus("↳ " + ui.history[i])
+			return
+		}
+	}
+	ui.showStatus("No older command contains " + text)
+}
+
+// Stop searching the history, and put the command in the command input
+func (ui *UI) endHistorySearch(command string) {
+	ui.searching = false
+	ui.historyIndex = len(ui.history)
+	ui.commandInput.SetLabel(commandLabel).SetText(command)
+	ui.showStatus("")
+}
+
+// The command input changed, update the history search if there is one
+func (ui *UI) commandChanged(text string) {
+	if ui.searching {
+		ui.findInHistory(text, len(ui.history)-1)
+	}
+}
+
+// Handle keys pressed while searching the history: Enter runs the found command, Esc brings
+// back what was being typed, other keys that move around put the found command in the input
+func (ui *UI) historySearchKeys(event *tcell.EventKey) *tcell.EventKey {
+	match := ui.historyDraft
+	if ui.searchMatch >= 0 {
+		match = ui.history[ui.searchMatch]
+	}
+	switch event.Key() {
+	case tcell.KeyCtrlR:
+		ui.searchHistory()
+	case tcell.KeyEnter:
+		if ui.searchMatch < 0 {
+			ui.endHistorySearch(ui.historyDraft)
+			return nil
+		}
+		ui.endHistorySearch(match)
+		return event
+	case tcell.KeyEscape:
+		ui.endHistorySearch(ui.historyDraft)
+	case tcell.KeyUp, tcell.KeyDown, tcell.KeyTab, tcell.KeyLeft, tcell.KeyRight:
+		ui.endHistorySearch(match)
+	default:
+		return event
+	}
+	return nil
+}
+
+// Complete the command being typed: a single completion replaces it, and more than one
+// are shown in a list to choose from
+func (ui *UI) completeCommand() {
+	entries := ui.completions(ui.commandInput.GetText())
+	if len(entries) == 1 {
+		ui.commandInput.SetText(entries[0])
+	} else if len(entries) > 1 {
+		ui.completing = true
+		ui.commandInput.Autocomplete()
+	}
+}
+
+// Return the completions to show in the command input's list, only once the user asked for them,
+// so the list doesn't get in the way of typing
+func (ui *UI) autocompleteEntries(text string) []string {
+	if !ui.completing {
+		return nil
+	}
+	entries := ui.completions(text)
+	if len(entries) == 0 {
+		ui.completing = false
+	}
+	return entries
+}
+
+// Return the ways to complete the last word of the command text: command names, activity
+// subcommands, activity names, profile names, template names, and wall-clock times to goto
+func (ui *UI) completions(text string) []string {
+	var prefix, word string
+	if quote := strings.LastIndexAny(text, `"'`); quote >= 0 && strings.Count(text, text[quote:quote+1])%2 == 1 {
+		prefix, word = text[:quote], text[quote:] // an unfinished quoted name
+	} else if space := strings.LastIndex(text, " "); space >= 0 {
+		prefix, word = text[:space+1], text[space+1:]
+	} else {
+		word = text
+	}
+	var candidates []string
+	fields := strings.Fields(strings.ToLower(prefix))
+	switch {
+	case len(fields) == 0:
+		candidates = ui.commandKeywords()
+	case fields[0] == "activity" && len(fields) == 1:
+		candidates = activitySubcommands
+	case fields[0] == "activity" && len(fields) == 2 && fields[1] != "add":
+		for _, activity := range ui.config.Activities {
+			candidates = append(candidates, quoteName(activity.Name))
+		}
+	case fields[0] == "profile" && len(fields) == 1:
+		candidates = ui.profileNames()
+	case fields[0] == "apply" && len(fields) == 1:
+		for _, name := range ui.config.TemplateNames() {
+			candidates = append(candidates, quoteName(name))
+		}
+	case fields[0] == "copy" && len(fields) == 1:
+		candidates = []string{"yesterday"}
+	case (fields[0] == "apply" || fields[0] == "copy") && len(fields) == 2:
+		candidates = copyModes
+	case fields[0] == "goto" && len(fields) == 1:
+		candidates = clockTimes(word != "")
+	}
+	entries := []string{}
+	for _, candidate := range candidates {
+		if strings.HasPrefix(strings.ToLower(candidate), strings.ToLower(word)) ||
+			strings.HasPrefix(strings.ToLower(candidate), strings.ToLower(strings.TrimLeft(word, `"'`))) {
+			entries = append(entries, prefix+candidate)
+		}
+	}
+	return entries
+}
+
+// Return the names of the commands, from the command registry
+func (ui *UI) commandKeywords() []string {
+	keywords := []string{}
+	for _, command := range ui.commands {
+		for _, usage := range strings.Split(command.usage, ", ") {
+			if keywordRegExp.MatchString(usage) {
+				keywords = append(keywords, usage)
+			} else if first := strings.Fields(usage)[0]; keywordRegExp.MatchString(first) {
+				keywords = append(keywords, first)
+			}
+		}
+	}
+	return keywords
+}
+
+// Return the names of the profiles in the config file, starting with the default profile
+func (ui *UI) profileNames() []string {
+	conf, err := storage.ReadConfig(ui.configFile)
+	if err != nil {
+		return []string{model.DefaultProfileName}
+	}
+	return model.ProfileNames(conf)
+}
+
+// Return the start of every time slice of the day in 24h time, or only the start of each hour
+func clockTimes(quarterHours bool) []string {
+	times := []string{}
+	for slice := 0; slice < 96; slice++ {
+		if quarterHours || slice%4 == 0 {
+			times = append(times, fmt.Sprintf("%d:%02d", slice/4, (slice%4)*15))
+		}
+	}
+	return times
+}
+
+// Return the name quoted if it has white space, so it's a single argument
+func quoteName(name string) string {
+	if strings.ContainsAny(name, " \t") {
+		return `"` + name + `"`
+	}
+	return name
+}
@@ -0,0 +1,49 @@
+package tui
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/seven-serverless-projects/bt/model"
+)
+
+// TestCompletions - test completing commands, activity names and times
+func TestCompletions(t *testing.T) {
+
+	type testCase struct {
+		text        string
+		completions []string
+	}
+
+	ui := &UI{config: model.Config{Profile: model.Profile{Activities: []model.Activity{
+		{ID: "1", Name: "Sleeping", Color: "08b4ff", Active: true},
+		{ID: "2", Name: "Board Games", Color: "ffc885", Active: false}},
+		Templates: map[string][]model.TemplateEntry{"workday": nil, "long weekend": nil}}}}
+	ui.commands = ui.initCommands()
+	testCases := []testCase{
+		{"to", []string{"today"}},
+		{"whole", []string{"whole day"}},
+		{"act", []string{"activity"}},
+		{"activity r", []string{"activity rename"}},
+		{"activity hide s", []string{"activity hide Sleeping"}},
+		{`activity show "bo`, []string{`activity show "Board Games"`}},
+		{"activity add S", []string{}},
+		{"goto 9", []string{"goto 9:00", "goto 9:15", "goto 9:30", "goto 9:45"}},
+		{"apply w", []string{"apply workday"}},
+		{`apply "lo`, []string{`apply "long weekend"`}},
+		{"apply workday o", []string{"apply workday overwrite"}},
+		{"copy y", []string{"copy yesterday"}},
+		{"t1 a", []string{}}}
+	t.Log("Test: completions...")
+	for i, testCase := range testCases {
+		completions := ui.completions(testCase.text)
+		if fmt.Sprint(completions) != fmt.Sprint(testCase.completions) {
+			t.Errorf("Test: completions FAIL - %v in test case %d", completions, i+1)
+		} else {
+			t.Log("Test: success for completion test case " + fmt.Sprint(i+1))
+		}
+	}
+	if hours := ui.completions("goto "); len(hours) != hoursPerDay || hours[9] != "goto 9:00" {
+		t.Errorf("Test: completions FAIL - hours %v", hours)
+	}
+}
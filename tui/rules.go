@@ -0,0 +1,26 @@
+package tui
+
+import (
+	"time"
+
+	"github.com/seven-serverless-projects/bt/model"
+	"github.com/seven-serverless-projects/bt/storage"
+)
+
+// Load the stored day for the date of the specified time. A day that's never been stored is given
+// the time the config's rules assign, marked as auto so the user's own entries override it. The
+// rules' time is only in memory until the day is first saved, so just viewing a day doesn't save it.
+func (ui *UI) loadDay(forDay time.Time) (model.Day, error) {
+	return ui.loadDayFrom(ui.store, ui.config, forDay)
+}
+
+// Load the day for the date of the specified time from the store, with the config's rules, see
+// loadDay. The store and config needn't be the UI's yet, e.g. when switching profiles.
+func (ui *UI) loadDayFrom(store storage.Store, conf model.Config, forDay time.Time) (model.Day, error) {
+	day, err := store.LoadDay(forDay)
+	if err != nil || day.Stored {
+		return day, err
+	}
+	_, err = conf.ApplyRules(&day)
+	return day, err
+}
@@ -0,0 +1,418 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2" // https://github.com/gdamore/tcell
+	"github.com/rivo/tview"       // https://github.com/rivo/tview
+)
+
+// Names of the UI's pages, the main grid and the modals shown on top of it
+const (
+	mainPage = "main"
+	menuPage = "menu"
+	notePage = "note"
+)
+
+const (
+	plainStyle          = "[::-]" // tview style tag that ends the style of a time slice
+	visualHelp          = "v select, j/k move, 1-9 assign, x unassign, Esc cancel, Tab command"
+	wholeDayPrefixWidth = 14 // the width of e.g. "t1 -t4   0:00 " before each hour's blocks in the whole day view
+	wholeDayBlockWidth  = 3  // the width of each time slice's block in the whole day view
+)
+
+// Selection - a range of the current day's time slices, selected in the UI
+type Selection struct {
+	active bool
+	anchor int // index in the day of the time slice the selection started from
+	cursor int // index in the day of the time slice the selection extends to
+}
+
+// Return true if the time slice with the specified index in the day is selected
+func (selection Selection) contains(slice int) bool {
+	if !selection.active {
+		return false
+	}
+	if selection.anchor <= selection.cursor {
+		return slice >= selection.anchor && slice <= selection.cursor
+	}
+	return slice >= selection.cursor && slice <= selection.anchor
+}
+
+// Return the indexes in the day of the selected time slices, in order, including those
+// scrolled out of view
+func (selection Selection) slices() []int {
+	slices := []int{}
+	if !selection.active {
+		return slices
+	}
+	first, last := selection.anchor, selection.cursor
+	if first > last {
+		first, last = last, first
+	}
+	for slice := first; slice <= last; slice++ {
+		slices = append(slices, slice)
+	}
+	return slices
+}
+
+// Return the indexes in the day of the time slices the keyboard and mouse act on: the selected
+// time slices, or the time slice under the keyboard cursor when nothing is selected and the time
+// slices have the focus
+func (ui *UI) targetSlices() []int {
+	if ui.selection.active || !ui.timeSliceList.HasFocus() {
+		return ui.selection.slices()
+	}
+	if _, displayed := ui.timeSliceIndexFor(ui.cursor); displayed {
+		return []int{ui.cursor}
+	}
+	return []int{}
+}
+
+// Return the indexes in the day of the displayed time slices with the specified t#'s
+func (ui *UI) slicesFor(timeSliceIndexes []int) []int {
+	slices := []int{}
+	for _, timeSliceIndex := range timeSliceIndexes {
+		slices = append(slices, ui.viewport.Start+timeSliceIndex-1)
+	}
+	return slices
+}
+
+// Return the t# of the time slice with the specified index in the day, false if it isn't displayed
+func (ui *UI) timeSliceIndexFor(slice int) (int, bool) {
+	if !ui.viewport.Contains(slice) {
+		return 0, false
+	}
+	return slice - ui.viewport.Start + 1, true
+}
+
+// Return the tview style tag for the time slice with the specified index in the day: reversed
+// colors when it's selected, and bold and underlined when the keyboard cursor is on it
+func (ui *UI) timeSliceStyle(slice int) string {
+	attributes := ""
+	if ui.selection.contains(slice) {
+		attributes += "r"
+	}
+	if slice == ui.cursor && ui.timeSliceList.HasFocus() {
+		attributes += "bu"
+	}
+	if attributes == "" {
+		return ""
+	}
+	return "[::" + attributes + "]"
+}
+
+// Select the time slices from the anchor to the cursor, by their index in the day, and rerender
+func (ui *UI) selectTimeSlices(anchor int, cursor int) {
+	ui.selection = Selection{true, anchor, cursor}
+	ui.syncUI()
+}
+
+// Clear any selected time slices and rerender
+func (ui *UI) clearSelection() {
+	if ui.selection.active {
+		ui.selection = Selection{}
+		ui.syncUI()
+	}
+}
+
+// Assign the activity with the specified a# to the selected time slices
+func (ui *UI) assignSelection(activityIndex int) {
+	slices := ui.targetSlices()
+	if len(slices) == 0 {
+		ui.showStatus("Select time slices first, by clicking or dragging over them")
+		return
+	}
+	if activityIndex < 1 || activityIndex > len(ui.config.ActiveActivities()) {
+		ui.showStatus(fmt.Sprintf("There's no activity a%d", activityIndex))
+		return
+	}
+	ui.selection = Selection{}
+	ui.assignSlices(slices, activityIndex)
+}
+
+// Unassign the activity from the selected time slices
+func (ui *UI) unassignSelection() {
+	slices := ui.targetSlices()
+	if len(slices) == 0 {
+		ui.showStatus("Select time slices first, by clicking or dragging over them")
+		return
+	}
+	ui.selection = Selection{}
+	ui.unassignSlices(slices)
+}
+
+// Move the focus to the time slices, so the keyboard moves a cursor over them to select and
+// assign them, starting a selection at the cursor if specified
+func (ui *UI) focusTimeSlices(startSelection bool) {
+	if ui.selection.active {
+		ui.cursor = ui.selection.cursor
+	}
+	if _, displayed := ui.timeSliceIndexFor(ui.cursor); !displayed {
+		ui.cursor = ui.viewport.Start
+	}
+	if startSelection {
+		ui.selection = Selection{true, ui.cursor, ui.cursor}
+	}
+	ui.app.SetFocus(ui.timeSliceList)
+	ui.showStatus(visualHelp)
+	ui.syncUI()
+}
+
+// Move the focus back to the command input from the time slices
+func (ui *UI) focusCommandInput() {
+	ui.app.SetFocus(ui.commandInput)
+	ui.showStatus("")
+	ui.syncUI()
+}
+
+// Move the keyboard cursor by the specified number of time slices, extending any selection,
+// and scroll the time slices to keep the cursor displayed
+func (ui *UI) moveCursor(slices int) {
+	ui.cursor += slices
+	if ui.cursor < 0 {
+		ui.cursor = 0
+	} else if ui.cursor > 95 {
+		ui.cursor = 95
+	}
+	if ui.selection.active {
+		ui.selection.cursor = ui.cursor
+	}
+	first, last := ui.viewport.Start, ui.viewport.End()
+	if ui.cursor < first {
+		ui.scrollTime(ui.cursor - first)
+	} else if ui.cursor > last {
+		ui.scrollTime(ui.cursor - last)
+	} else {
+		ui.syncUI()
+	}
+}
+
+// Handle keys pressed while the time slices have the focus, moving the cursor, selecting,
+// and assigning. All keys are consumed, so they don't scroll the text of the time slices.
+func (ui *UI) visualKeys(event *tcell.EventKey) *tcell.EventKey {
+	switch event.Key() {
+	case tcell.KeyDown:
+		ui.moveCursor(1)
+	case tcell.KeyUp:
+		ui.moveCursor(-1)
+	case tcell.KeyPgDn:
+		ui.moveCursor(ui.viewport.Size)
+	case tcell.KeyPgUp:
+		ui.moveCursor(-ui.viewport.Size)
+	case tcell.KeyHome:
+		ui.moveCursor(-ui.cursor)
+	case tcell.KeyEnd:
+		ui.moveCursor(95 - ui.cursor)
+	case tcell.KeyTab:
+		ui.focusCommandInput()
+	case tcell.KeyEscape:
+		if ui.selection.active {
+			ui.clearSelection()
+		} else {
+			ui.focusCommandInput()
+		}
+	case tcell.KeyRune:
+		switch r := event.Rune(); {
+		case r == 'j':
+			ui.moveCursor(1)
+		case r == 'k':
+			ui.moveCursor(-1)
+		case r == 'v':
+			if ui.selection.active {
+				ui.clearSelection()
+			} else {
+				ui.selectTimeSlices(ui.cursor, ui.cursor)
+			}
+		case r == 'x':
+			ui.unassignSelection()
+		case r >= '1' && r <= '9':
+			ui.assignSelection(int(r - '0'))
+		case r == 'i' || r == ':':
+			ui.focusCommandInput()
+		case r == '?':
+			ui.showHelp()
+		}
+	}
+	return nil
+}
+
+// Return the index in the day of the time slice displayed at the screen position,
+// false if there isn't one there
+func (ui *UI) timeSliceAt(x int, y int) (int, bool) {
+	if !ui.timeSliceList.InRect(x, y) {
+		return 0, false
+	}
+	innerX, innerY, _, _ := ui.timeSliceList.GetInnerRect()
+	scrollRow, _ := ui.timeSliceList.GetScrollOffset()
+	row := y - innerY + scrollRow
+	index := row / rowsPerTimeSlice
+	if ui.wholeDay {
+		block := (x - innerX - wholeDayPrefixWidth) / wholeDayBlockWidth
+		if block < 0 {
+			block = 0
+		} else if block > 3 {
+			block = 3
+		}
+		index = (row * 4) + block
+	}
+	if row < 0 || index >= ui.viewport.Size {
+		return 0, false
+	}
+	return ui.viewport.Start + index, true
+}
+
+// Return the a# of the activity displayed at the screen position, false if there isn't one there
+func (ui *UI) activityAt(x int, y int) (int, bool) {
+	if !ui.activityList.InRect(x, y) {
+		return 0, false
+	}
+	_, innerY, _, _ := ui.activityList.GetInnerRect()
+	scrollRow, _ := ui.activityList.GetScrollOffset()
+	row := y - innerY + scrollRow
+	for i, activity := range ui.config.ActiveActivities() {
+		rows := len(activity.Goals()) + 2 // each activity is followed by its goals and a blank line
+		if row >= 0 && row < rows {
+			return i + 1, true
+		}
+		row -= rows
+	}
+	return 0, false
+}
+
+// Handle the mouse over the time slices: click or drag to select time slices, right click
+// for a menu of what to do with them, and scroll the wheel to scroll through the day.
+// Mouse events are consumed so the command input keeps the focus.
+func (ui *UI) timeSliceMouse(action tview.MouseAction, event *tcell.EventMouse) (tview.MouseAction, *tcell.EventMouse) {
+	slice, onTimeSlice := ui.timeSliceAt(event.Position())
+	switch action {
+	case tview.MouseLeftDown:
+		ui.dragging = onTimeSlice
+		if onTimeSlice {
+			ui.selectTimeSlices(slice, slice)
+		} else {
+			ui.clearSelection()
+		}
+	case tview.MouseMove:
+		if ui.dragging && onTimeSlice && event.Buttons()&tcell.Button1 != 0 {
+			ui.selectTimeSlices(ui.selection.anchor, slice)
+		}
+	case tview.MouseLeftUp:
+		if ui.dragging {
+			ui.dragging = false
+			ui.showStatus("Click an activity, or press its number, to assign it")
+		}
+	case tview.MouseRightClick:
+		if onTimeSlice {
+			if !ui.selection.contains(slice) {
+				ui.selectTimeSlices(slice, slice)
+			}
+			ui.showTimeSliceMenu()
+		}
+	case tview.MouseScrollUp:
+		ui.scrollTime(-1)
+	case tview.MouseScrollDown:
+		ui.scrollTime(1)
+	case tview.MouseLeftClick, tview.MouseRightDown, tview.MouseRightUp:
+		// handled by the down and up actions
+	default:
+		return action, event
+	}
+	return action, nil
+}
+
+// Handle the mouse over the activities: click an activity to assign it to the selected time slices.
+// Mouse events are consumed so the command input keeps the focus.
+func (ui *UI) activityMouse(action tview.MouseAction, event *tcell.EventMouse) (tview.MouseAction, *tcell.EventMouse) {
+	switch action {
+	case tview.MouseLeftClick:
+		if activityIndex, onActivity := ui.activityAt(event.Position()); onActivity {
+			ui.assignSelection(activityIndex)
+		}
+	case tview.MouseLeftDown, tview.MouseLeftUp:
+		// handled by the click action
+	default:
+		return action, event
+	}
+	return action, nil
+}
+
+// Show a menu of what can be done to the selected time slices
+func (ui *UI) showTimeSliceMenu() {
+	slices := ui.selection.slices()
+	if len(slices) == 0 {
+		return
+	}
+	menu := tview.NewModal().
+		SetText(ui.spanText(slices)).
+		AddButtons([]string{"Unassign", "Note", "Cancel"}).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			ui.hideModal(menuPage)
+			switch buttonLabel {
+			case "Unassign":
+				ui.selection = Selection{}
+				ui.unassignSlices(slices)
+			case "Note":
+				ui.showNoteForm(slices)
+			default:
+				ui.clearSelection()
+			}
+		})
+	ui.pages.AddPage(menuPage, menu, false, true)
+	ui.app.SetFocus(menu)
+}
+
+// Return the time the time slices with the specified indexes in the day span, with their t#'s
+// when they're displayed, e.g. t1-t4: 9:00 - 10:00
+func (ui *UI) spanText(slices []int) string {
+	first := strings.Split(timeDisplayFor(ui.currentDay.TimeSlices[slices[0]]), " - ")
+	last := strings.Split(timeDisplayFor(ui.currentDay.TimeSlices[slices[len(slices)-1]]), " - ")
+	text := first[0] + " - " + strings.TrimSpace(last[1])
+	firstIndex, firstDisplayed := ui.timeSliceIndexFor(slices[0])
+	lastIndex, lastDisplayed := ui.timeSliceIndexFor(slices[len(slices)-1])
+	if firstDisplayed && lastDisplayed {
+		text = fmt.Sprintf("t%d-t%d: %s", firstIndex, lastIndex, text)
+	}
+	return text
+}
+
+// Show a form for the note of the time slices with the specified indexes in the day
+func (ui *UI) showNoteForm(slices []int) {
+	title := ui.spanText(slices)
+	form := tview.NewForm().
+		AddInputField("Note", ui.currentDay.TimeSlices[slices[0]].Note, 40, nil, nil)
+	save := func() {
+		note := strings.TrimSpace(form.GetFormItem(0).(*tview.InputField).GetText())
+		ui.hideModal(notePage)
+		ui.selection = Selection{}
+		ui.noteSlices(slices, note)
+	}
+	cancel := func() {
+		ui.hideModal(notePage)
+		ui.clearSelection()
+	}
+	form.AddButton("Save", save).
+		AddButton("Cancel", cancel).
+		SetCancelFunc(cancel)
+	form.SetBorder(true).SetTitle(" Note for " + title + " ")
+	ui.showModal(notePage, form, 56, 7)
+}
+
+// Show the primitive centered over the rest of the UI, with the focus
+func (ui *UI) showModal(name string, primitive tview.Primitive, width int, height int) {
+	ui.pages.AddPage(name, tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(primitive, height, 0, true).
+			AddItem(nil, 0, 1, false), width, 0, true).
+		AddItem(nil, 0, 1, false), true, true)
+	ui.app.SetFocus(primitive)
+}
+
+// Remove the modal from the UI and give the focus back to the command input
+func (ui *UI) hideModal(name string) {
+	ui.pages.RemovePage(name)
+	ui.app.SetFocus(ui.commandInput)
+}
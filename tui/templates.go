@@ -0,0 +1,99 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/seven-serverless-projects/bt/model"
+)
+
+// Ways of copying time slices into the current day: filling only the untracked time, or also
+// replacing the activities of time that's already assigned
+var copyModes = []string{"merge", "overwrite"}
+
+// Assign the time slices of the current day from the named day template in the config, given
+// the arguments of the apply command
+func (ui *UI) applyTemplate(args []string) {
+	name, overwrite, err := copyArgs(args, "apply TEMPLATE")
+	if err != nil {
+		ui.showError(err)
+		return
+	}
+	forDay, err := ui.currentDay.Time()
+	if err != nil {
+		ui.showError(err)
+		return
+	}
+	template, name, err := ui.config.TemplateDay(name, forDay)
+	if err != nil {
+		ui.showError(err)
+		return
+	}
+	ui.copyTimeSlices(template, overwrite, "the "+name+" template")
+}
+
+// Assign the time slices of the current day from another stored day, yesterday being the day
+// before the current day, given the arguments of the copy command
+func (ui *UI) copyDay(args []string) {
+	date, overwrite, err := copyArgs(args, "copy yesterday|YYYY-MM-DD")
+	if err != nil {
+		ui.showError(err)
+		return
+	}
+	current, err := ui.currentDay.Time()
+	if err != nil {
+		ui.showError(err)
+		return
+	}
+	from := current.AddDate(0, 0, -1)
+	if !strings.EqualFold(date, "yesterday") {
+		if from, err = time.ParseInLocation(model.DateFormat, date, time.Local); err != nil {
+			ui.showError(fmt.Errorf("%q is not a date, e.g. 2020-10-26", date))
+			return
+		}
+	}
+	if from.Format(model.DateFormat) == ui.currentDay.Date {
+		ui.showError(errors.New("a day can't be copied into itself"))
+		return
+	}
+	fromDay, err := ui.store.LoadDay(from)
+	if err != nil {
+		ui.showError(fmt.Errorf("%w (try again)", err))
+		return
+	}
+	ui.copyTimeSlices(fromDay, overwrite, fromDay.Date)
+}
+
+// Copy the time slices of the other day into the current day and persist the update, describing
+// where they came from in the status
+func (ui *UI) copyTimeSlices(from model.Day, overwrite bool, source string) {
+	copied := ui.currentDay.Copy(from, overwrite)
+	if copied == 0 {
+		ui.showStatus("Nothing to copy from " + source)
+		return
+	}
+	ui.syncUI()
+	if err := ui.store.SaveDay(ui.currentDay); err != nil {
+		ui.showError(err)
+		return
+	}
+	ui.showStatus(fmt.Sprintf("Copied %s from %s", model.SlicesText(copied), source))
+}
+
+// Return the first of the arguments of a command that copies time slices, and true if the
+// optional mode after it is overwrite rather than merge
+func copyArgs(args []string, usage string) (string, bool, error) {
+	if len(args) == 1 {
+		return args[0], false, nil
+	}
+	if len(args) == 2 {
+		for _, mode := range copyModes {
+			if strings.EqualFold(args[1], mode) {
+				return args[0], mode == "overwrite", nil
+			}
+		}
+	}
+	return "", false, fmt.Errorf("usage: %s [%s]", usage, strings.Join(copyModes, "|"))
+}
@@ -0,0 +1,206 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/seven-serverless-projects/bt/model"
+	"github.com/seven-serverless-projects/bt/storage"
+)
+
+// Read the running timer from the store, which may have been started or stopped by bt on
+// another machine
+func (ui *UI) loadTimer() error {
+	timer, err := ui.store.LoadTimer()
+	if err != nil {
+		return err
+	}
+	ui.timer = timer
+	return nil
+}
+
+// Start timing the activity live from now, stopping the timer first if it's timing another,
+// after reloading it as bt on another machine may have stopped it already
+func (ui *UI) startTimer(activityIndex int) {
+	activity := ui.config.ActiveActivities()[activityIndex-1]
+	now := ui.clock.Now()
+	if err := ui.loadTimer(); err != nil {
+		ui.showError(err)
+		return
+	}
+	if ui.timer.Running() {
+		if err := ui.assignTimer(now, true); err != nil {
+			ui.showError(err)
+			return
+		}
+	}
+	timer := model.Timer{ActivityID: activity.ID, Start: now}
+	if err := ui.store.SaveTimer(timer); err != nil {
+		ui.showError(err)
+		return
+	}
+	ui.timer = timer
+	ui.syncUI()
+	ui.showStatus("Started timing " + activity.Name)
+}
+
+// Stop the timer, assigning the time slices it ran through, including the one in progress if
+// it's more than half way through. The timer is reloaded first, as bt on another machine may
+// have stopped it already.
+func (ui *UI) stopTimer() {
+	if err := ui.loadTimer(); err != nil {
+		ui.showError(err)
+		return
+	}
+	if !ui.timer.Running() {
+		ui.showStatus("The timer isn't running, try: start a#")
+		return
+	}
+	now := ui.clock.Now()
+	name := ui.config.ActivityByID(ui.timer.ActivityID).Name
+	elapsed := now.Sub(ui.timer.Start)
+	if err := ui.assignTimer(now, true); err != nil {
+		ui.showError(err)
+		return
+	}
+	if err := ui.store.SaveTimer(model.Timer{}); err != nil {
+		ui.showError(err)
+		return
+	}
+	ui.timer = model.Timer{}
+	ui.syncUI()
+	ui.showStatus(fmt.Sprintf("Stopped timing %s after %s", name, elapsedText(elapsed)))
+}
+
+// Assign the time slices the running timer has completed as of now to its activity, and persist
+// them and how far the timer's been assigned through. Time slices of days other than the current
+// day are loaded and saved in the store. The timer isn't saved if bt on another machine has
+// stopped or restarted it since it was loaded, so it isn't started again.
+func (ui *UI) assignTimer(now time.Time, stopping bool) error {
+	spans, through := ui.timer.Completed(now, stopping)
+	if len(spans) == 0 {
+		return nil
+	}
+	for _, daySpan := range spans {
+		if daySpan.Day.Format(model.DateFormat) == ui.currentDay.Date {
+			ui.currentDay.Assign(daySpan.Span, ui.timer.ActivityID)
+			if err := ui.store.SaveDay(ui.currentDay); err != nil {
+				return err
+			}
+			continue
+		}
+		day, err := ui.loadDay(daySpan.Day)
+		if err != nil {
+			return err
+		}
+		day.Assign(daySpan.Span, ui.timer.ActivityID)
+		if err := ui.store.SaveDay(day); err != nil {
+			return err
+		}
+	}
+	ui.timer.Through = through
+	if stopping {
+		return nil
+	}
+	stored, err := ui.store.LoadTimer()
+	if err != nil {
+		return err
+	}
+	if !stored.Same(ui.timer) {
+		ui.timer = stored
+		return nil
+	}
+	return ui.store.SaveTimer(ui.timer)
+}
+
+// Reload the timer from the store, as bt on another machine may have stopped it, and assign the
+// time slices it has completed as of now to its activity. Runs outside of the UI's event loop, so
+// a slow store doesn't freeze the UI, and applies the results in it. The time slices of the day
+// being shown are assigned in the event loop, so the user's own changes to it aren't overwritten.
+func (ui *UI) tickTimer(store storage.Store, now time.Time) {
+	defer ui.app.QueueUpdate(func() { ui.tickingTimer = false })
+	timer, err := store.LoadTimer()
+	if err != nil {
+		ui.app.QueueUpdateDraw(func() { ui.showError(err) })
+		return
+	}
+	spans, through := timer.Completed(now, false)
+	var conf model.Config
+	others := []model.DaySpan{} // the spans of days other than the one being shown
+	current := false
+	ui.app.QueueUpdateDraw(func() {
+		if ui.store != store {
+			return // switched profiles, so the timer is another's
+		}
+		current = true
+		ui.timer, conf = timer, ui.config
+		assigned := 0
+		for _, daySpan := range spans {
+			if daySpan.Day.Format(model.DateFormat) == ui.currentDay.Date {
+				assigned += ui.currentDay.Assign(daySpan.Span, timer.ActivityID)
+			} else {
+				others = append(others, daySpan)
+			}
+		}
+		if assigned > 0 {
+			if err := ui.store.SaveDay(ui.currentDay); err != nil {
+				ui.showError(err)
+			}
+		}
+		ui.syncUI()
+	})
+	if !current || len(spans) == 0 {
+		return
+	}
+	for _, daySpan := range others {
+		day, err := ui.loadDayFrom(store, conf, daySpan.Day)
+		if err == nil {
+			day.Assign(daySpan.Span, timer.ActivityID)
+			err = store.SaveDay(day)
+		}
+		if err != nil {
+			ui.app.QueueUpdateDraw(func() { ui.showError(err) })
+			return
+		}
+	}
+
+	// Unless the timer's been stopped or restarted since it was loaded, here or on another machine
+	timer.Through = through
+	stored, err := store.LoadTimer()
+	if err == nil && stored.Same(timer) {
+		stored, err = timer, store.SaveTimer(timer)
+	}
+	ui.app.QueueUpdateDraw(func() {
+		if err != nil {
+			ui.showError(err)
+		} else if ui.store == store && ui.timer.Same(timer) {
+			ui.timer = stored
+			ui.syncUI()
+		}
+	})
+}
+
+// Return the running timer for the header, e.g. ▶ Writing 1h 5m, blank if it isn't running
+func (ui *UI) timerText() string {
+	if !ui.timer.Running() {
+		return ""
+	}
+	activity := ui.config.ActivityByID(ui.timer.ActivityID)
+	name := activity.Name
+	if activity.ID == "" {
+		name = deletedActivityName
+	}
+	return "▶ " + name + " " + elapsedText(ui.clock.Now().Sub(ui.timer.Start))
+}
+
+// Return the length of time in whole minutes, e.g. 1h 5m or 0m
+func elapsedText(elapsed time.Duration) string {
+	minutes := int(elapsed.Minutes())
+	if minutes < 0 {
+		minutes = 0
+	}
+	if minutes < 60 {
+		return fmt.Sprintf("%dm", minutes)
+	}
+	return fmt.Sprintf("%dh %dm", minutes/60, minutes%60)
+}
@@ -0,0 +1,1064 @@
+// Package tui is the BubbleTimer terminal user interface. Everything it uses, the config and
+// the store of the days, is passed to New, so more than one can be created.
+package tui
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2" // https://github.com/gdamore/tcell
+	"github.com/rivo/tview"       // https://github.com/rivo/tview
+
+	"github.com/seven-serverless-projects/bt/model"
+	"github.com/seven-serverless-projects/bt/parse"
+	"github.com/seven-serverless-projects/bt/storage"
+)
+
+const (
+	deletedActivityName = "(deleted activity)"
+	configPollInterval  = 2 * time.Second
+	clockInterval       = time.Minute
+	headerRows          = 3
+	footerRows          = 3
+	rowsPerTimeSlice    = 2  // each time slice is followed by a blank line
+	defaultDisplayed    = 12 // the time slices displayed until the size of the terminal is known
+	hoursPerDay         = 24
+	bgColor             = tcell.ColorDarkBlue
+	formatUS            = "Monday, January 2, 2006"
+)
+
+// Options - what the UI is started with: the config, where it came from, and the store of the days
+type Options struct {
+	Config     model.Config // the config with the profile's fields in effect, see model.ProfileConfig
+	ConfigFile string
+	Profile    string // blank for the top level profile of the config
+	DataDir    string // where the command history is kept
+	Store      storage.Store
+	// Connect returns a store for the named profile's config, when switching to a profile that stores its days elsewhere
+	Connect func(conf model.Config, profile string) (storage.Store, error)
+	Clock   model.Clock  // the system clock if not set
+	Screen  tcell.Screen // the terminal if not set, e.g. a tcell.SimulationScreen in tests
+}
+
+// UI - the BubbleTimer terminal user interface
+type UI struct {
+	config         model.Config
+	configFile     string
+	profile        string // blank for the top level profile of the config
+	dataDir        string
+	store          storage.Store
+	connect        func(conf model.Config, profile string) (storage.Store, error)
+	clock          model.Clock // where the current time comes from, the only place the UI reads it
+	currentDay     model.Day
+	timer          model.Timer // the activity being timed live, if the timer's running
+	commands       []Command   // the commands the user can type, see initCommands
+	app            *tview.Application
+	pages          *tview.Pages // the grid, with any modal shown on top of it
+	grid           *tview.Grid
+	header         *tview.TextView
+	timeSliceList  *tview.TextView
+	activityList   *tview.TextView
+	commandInput   *tview.InputField
+	status         *tview.TextView
+	viewport       model.Viewport // the time slices displayed, t1 to t<Size>, it follows the height of the terminal, or is the whole day
+	wholeDay       bool           // show every time slice of the day, compressed to a line per hour
+	wholeDayReturn model.Viewport // the viewport to return to after showing the whole day
+	weekSlices     map[string]int // time slices spent on each activity on the other days of the current day's week, nil unless there are weekly goals
+	selection      Selection      // time slices selected with the mouse or keyboard
+	cursor         int            // index in the day of the time slice the keyboard is on, when the time slices have the focus
+	lastTick       time.Time      // the current time as of the last tick of the clock
+	tickingTimer   bool           // the timer is being reloaded and assigned outside the event loop, see tickTimer
+	history        []string       // the commands entered, oldest first
+	historyIndex   int            // the command recalled from the history, the length of the history when there isn't one
+	historyDraft   string         // what was being typed before recalling or searching the history
+	searching      bool           // searching the history for a command
+	searchMatch    int            // the index of the command found by the history search, -1 if none
+	completing     bool           // the list of completions of the command is shown
+	dragging       bool           // the left mouse button is down, extending the selection
+}
+
+// New - return the UI for the options, showing today, ready to run
+func New(options Options) (*UI, error) {
+	ui := &UI{
+		config:     options.Config,
+		configFile: options.ConfigFile,
+		profile:    options.Profile,
+		dataDir:    options.DataDir,
+		store:      options.Store,
+		connect:    options.Connect,
+		clock:      options.Clock,
+		app:        tview.NewApplication(),
+	}
+	if ui.clock == nil {
+		ui.clock = model.SystemClock{}
+	}
+	ui.lastTick = ui.clock.Now()
+	if options.Screen != nil {
+		// The application only initializes the screens it creates
+		if err := options.Screen.Init(); err != nil {
+			return nil, fmt.Errorf("unable to initialize the screen: %w", err)
+		}
+		ui.app.SetScreen(options.Screen)
+	}
+	var err error
+	ui.currentDay, err = ui.loadDay(ui.lastTick)
+	if err != nil {
+		return nil, err
+	}
+	if err := ui.loadTimer(); err != nil {
+		return nil, err
+	}
+	// Catch up with the time the timer ran while bt wasn't running
+	if err := ui.assignTimer(ui.lastTick, false); err != nil {
+		return nil, err
+	}
+	if err := ui.loadWeek(); err != nil {
+		return nil, err
+	}
+	ui.commands = ui.initCommands()
+	if err := ui.initHeader(); err != nil {
+		return nil, err
+	}
+	ui.initTimeSlices()
+	ui.initActivities()
+	ui.initFooter()
+	ui.initGrid()
+	history, err := storage.LoadHistory(ui.historyFilePath())
+	if err != nil {
+		return nil, err
+	}
+	ui.history, ui.historyIndex = history, len(history)
+
+	return ui, nil
+}
+
+// Run - run the UI until the user quits, blocking
+func (ui *UI) Run() error {
+	done := make(chan struct{}) // closed when the UI stops running, to stop watching
+	defer close(done)
+	go ui.watchConfig(configPollInterval, done)
+	go ui.watchClock(clockInterval, done)
+	go ui.warnUnknownActivities(ui.store, ui.config.Activities)
+	return ui.app.Run()
+}
+
+// Stop - stop the UI and restore the terminal, safe to call more than once
+func (ui *UI) Stop() {
+	if ui != nil && ui.app != nil {
+		ui.app.Stop()
+	}
+}
+
+// Close - close the store of the days the UI is using
+func (ui *UI) Close() error {
+	return ui.store.Close()
+}
+
+func (ui *UI) initHeader() error {
+	thisDay, err := ui.currentDay.Time()
+	if err != nil {
+		return err
+	}
+	ui.header = tview.NewTextView().
+		SetTextAlign(tview.AlignCenter).
+		SetText(ui.headerText(thisDay))
+	ui.header.SetBorderPadding(1, 1, 0, 0)
+	ui.header.SetTextColor(tcell.ColorLimeGreen)
+	ui.header.SetBackgroundColor(bgColor)
+	return nil
+}
+
+func (ui *UI) initTimeSlices() {
+	ui.timeSliceList = tview.NewTextView().SetDynamicColors(true)
+	ui.timeSliceList.SetBorderPadding(0, 0, 1, 1).
+		SetBackgroundColor(bgColor)
+	ui.timeSliceList.SetInputCapture(ui.visualKeys)
+	ui.viewport = model.ViewportForTime(ui.lastTick, defaultDisplayed)
+	ui.timeSliceList.SetText(ui.timeSliceText())
+}
+
+func (ui *UI) initActivities() {
+	ui.activityList = tview.NewTextView().SetDynamicColors(true)
+	ui.activityList.SetBorderPadding(0, 0, 1, 1).
+		SetBackgroundColor(bgColor)
+	ui.activityList.SetText(ui.activityText())
+}
+
+func (ui *UI) initFooter() {
+	ui.commandInput = tview.NewInputField().
+		SetLabel(commandLabel).
+		SetFieldWidth(25).
+		SetFieldBackgroundColor(bgColor).
+		SetFieldTextColor(tcell.ColorYellow).
+		SetLabelColor(tcell.ColorGreen).
+		SetDoneFunc(ui.inputComplete).
+		SetChangedFunc(ui.commandChanged).
+		SetAutocompleteFunc(ui.autocompleteEntries).
+		SetText("")
+	ui.commandInput.SetInputCapture(ui.navigationKeys)
+	ui.commandInput.SetBorderPadding(1, 1, 1, 1)
+	ui.commandInput.SetBackgroundColor(bgColor)
+	ui.status = tview.NewTextView().
+		SetTextAlign(tview.AlignRight).
+		SetTextColor(tcell.ColorOrangeRed)
+	ui.status.SetBorderPadding(1, 1, 1, 1)
+	ui.status.SetBackgroundColor(bgColor)
+}
+
+func (ui *UI) initGrid() {
+	ui.grid = tview.NewGrid().
+		SetRows(headerRows, 0, footerRows).
+		SetColumns(0, 0).
+		AddItem(ui.header, 0, 0, 1, 2, 0, 0, false).
+		AddItem(ui.timeSliceList, 1, 0, 1, 1, 0, 0, false).
+		AddItem(ui.activityList, 1, 1, 1, 1, 0, 0, false).
+		AddItem(ui.commandInput, 2, 0, 1, 1, 0, 0, true).
+		AddItem(ui.status, 2, 1, 1, 1, 0, 0, false)
+	ui.pages = tview.NewPages().
+		AddPage(mainPage, ui.grid, true, true)
+	ui.app.SetRoot(ui.pages, true)
+	ui.app.SetFocus(ui.commandInput)
+	ui.app.EnableMouse(true)
+	ui.timeSliceList.SetMouseCapture(ui.timeSliceMouse)
+	ui.activityList.SetMouseCapture(ui.activityMouse)
+	ui.app.SetBeforeDrawFunc(func(screen tcell.Screen) bool {
+		_, height := screen.Size()
+		ui.resizeTimeSlices(height - headerRows - footerRows)
+		return false
+	})
+}
+
+// Fit the number of time slices displayed to the rows available for them, keeping the
+// time slice at the viewport's anchor, and refresh the UI if the number changes
+func (ui *UI) resizeTimeSlices(rows int) {
+	if displayed := ui.timeSlicesForRows(rows); displayed != ui.viewport.Size {
+		ui.viewport = ui.viewport.Resize(displayed)
+		ui.syncUI()
+	}
+}
+
+// Return the time slices of the current day that are displayed, t1 first. Changing them
+// changes the current day.
+func (ui *UI) timeSlices() []model.TimeSlice {
+	return ui.viewport.TimeSlices(&ui.currentDay)
+}
+
+// Return the number of time slices to display in the specified number of rows
+func (ui *UI) timeSlicesForRows(rows int) int {
+	displayed := (rows + 1) / rowsPerTimeSlice // the last time slice doesn't need a blank line
+	if ui.wholeDay {
+		displayed = len(ui.currentDay.TimeSlices)
+	}
+	if displayed < 1 {
+		displayed = 1
+	} else if displayed > len(ui.currentDay.TimeSlices) {
+		displayed = len(ui.currentDay.TimeSlices)
+	}
+	return displayed
+}
+
+// Switch between showing the whole day compressed, and showing the time slices that fit
+func (ui *UI) toggleWholeDay() {
+	ui.wholeDay = !ui.wholeDay
+	_, _, _, rows := ui.timeSliceList.GetInnerRect()
+	if ui.wholeDay {
+		ui.wholeDayReturn = ui.viewport
+		ui.viewport = model.ViewportStartingAt(0, ui.timeSlicesForRows(rows))
+		ui.showStatus("Showing the whole day, t1 is 0:00")
+	} else {
+		ui.viewport = ui.wholeDayReturn.Resize(ui.timeSlicesForRows(rows))
+		ui.showStatus("")
+	}
+	ui.syncUI()
+}
+
+func (ui *UI) resetInput() {
+	if ui.commandInput != nil {
+		ui.commandInput.SetText("")
+	}
+}
+
+// Show a short message to the user in the footer, a blank message clears it
+func (ui *UI) showStatus(message string) {
+	if ui.status != nil {
+		ui.status.SetText(message)
+	}
+}
+
+// Show an error to the user in the footer, rather than crashing the UI
+func (ui *UI) showError(err error) {
+	ui.showStatus(fmt.Sprintf("%v", err))
+}
+
+// Return the header for the day being shown, including the profile if it's not the default,
+// and the timer if it's running
+func (ui *UI) headerText(day time.Time) string {
+	header := day.Format(formatUS)
+	if ui.profile != "" {
+		header += " — " + ui.profile
+	}
+	if timer := ui.timerText(); timer != "" {
+		header += " — " + timer
+	}
+	return header
+}
+
+// Return a string suitable for use in the UI with a return delimitted entry for each timeslice we are displaying
+func (ui *UI) timeSliceText() string {
+	if ui.wholeDay {
+		return ui.wholeDayText()
+	}
+	timeSliceText := ""
+	for i, timeSlice := range ui.timeSlices() {
+		timeSliceText += ui.timeSliceStyle(timeSlice.Slice)
+		label := "t" + fmt.Sprint(i+1) + " — " + timeDisplayFor(timeSlice)
+		if timeSlice.Slice == ui.nowSlice() {
+			label = nowText(label)
+		}
+		timeSliceText += label
+		if timeSlice.ActivityID != "" {
+			activity := ui.config.ActivityByID(timeSlice.ActivityID)
+			if activity.ID == "" {
+				timeSliceText += " — " + dimText(deletedActivityName)
+			} else if !activity.Active {
+				timeSliceText += " — " + dimText(activity.Name)
+			} else if activity.Name != "" {
+				timeSliceText += " — " + tview.Escape(activity.Name)
+			}
+			if timeSlice.Auto {
+				timeSliceText += " " + dimText("(auto)")
+			}
+		}
+		if timeSlice.Note != "" {
+			timeSliceText += " — " + tview.Escape(timeSlice.Note)
+		}
+		if timeSlice.Slice == ui.nowSlice() {
+			timeSliceText += " " + nowText("◂ now")
+		}
+		timeSliceText += plainStyle + "\n\n"
+	}
+	return timeSliceText
+}
+
+// Return a string suitable for use in the UI with a line for each hour of the day, showing
+// each of the hour's time slices as a block in the color of its activity
+func (ui *UI) wholeDayText() string {
+	wholeDayText := ""
+	timeSlices := ui.timeSlices()
+	for hour := 0; hour < hoursPerDay && (hour*4) < len(timeSlices); hour++ {
+		first := hour * 4
+		label := fmt.Sprintf("t%-2d-t%-2d %2d:00 ", first+1, first+4, hour)
+		if now := ui.nowSlice(); now >= 0 && now/4 == hour {
+			label = nowText(label)
+		}
+		wholeDayText += label
+		for _, timeSlice := range timeSlices[first:(first + 4)] {
+			activity := ui.config.ActivityByID(timeSlice.ActivityID)
+			wholeDayText += " "
+			wholeDayText += ui.timeSliceStyle(timeSlice.Slice)
+			if timeSlice.ActivityID == "" {
+				wholeDayText += dimText("··")
+			} else if activity.ID == "" {
+				wholeDayText += dimText("██")
+			} else {
+				wholeDayText += colorText("██", activity.Color)
+			}
+			wholeDayText += plainStyle
+		}
+		wholeDayText += "\n"
+	}
+	return wholeDayText
+}
+
+// Return a string suitable for use in the UI with a return delimitted entry for each activity we are displaying
+func (ui *UI) activityText() string {
+	activeActivityCount := 1
+	activityText := ""
+	for _, activity := range ui.config.ActiveActivities() {
+		activityText += "a" + fmt.Sprint(activeActivityCount) + " — " + colorText(activity.Name, activity.Color)
+		timeInActivity := ui.timeInActivityText(activity.ID)
+		if timeInActivity != "" {
+			activityText += " — " + timeInActivity
+		}
+		for _, goal := range activity.Goals() {
+			activityText += "\n" + ui.goalText(activity.ID, goal)
+		}
+		activityText += "\n\n"
+		activeActivityCount++
+	}
+	// Activities that aren't active are only shown for days they were used on, dimmed, and
+	// without an a# since time can't be assigned to them
+	for _, activity := range ui.config.Activities {
+		if timeInActivity := ui.timeInActivityText(activity.ID); !activity.Active && timeInActivity != "" {
+			activityText += dimText("   "+activity.Name+" (inactive) — "+timeInActivity) + "\n\n"
+		}
+	}
+	if timeInActivity := ui.timeInDeletedActivitiesText(); timeInActivity != "" {
+		activityText += dimText("   "+deletedActivityName+" — "+timeInActivity) + "\n\n"
+	}
+	return activityText
+}
+
+// Return the text wrapped in tview color tags for the activity's hex color, e.g. 08b4ff,
+// with any tags in the text itself escaped
+func colorText(text string, color string) string {
+	if !model.ValidColor(color) {
+		return tview.Escape(text)
+	}
+	return "[#" + strings.TrimPrefix(color, "#") + "]" + tview.Escape(text) + "[-]"
+}
+
+// Return the text dimmed with tview color tags, with any tags in the text itself escaped
+func dimText(text string) string {
+	return "[gray]" + tview.Escape(text) + "[-]"
+}
+
+// Return the text highlighted with tview color tags as containing the current time,
+// with any tags in the text itself escaped
+func nowText(text string) string {
+	return "[limegreen]" + tview.Escape(text) + "[-]"
+}
+
+// Return the index in the day of the time slice containing the current time, as of the
+// last tick of the clock, or -1 if the day being shown isn't today
+func (ui *UI) nowSlice() int {
+	if ui.currentDay.Date != ui.lastTick.Format(model.DateFormat) {
+		return -1
+	}
+	return model.SliceForTime(ui.lastTick)
+}
+
+// Takes a time slice and returns a human readable string representing the starting and ending time of the time slice.
+// Currently in 24h time only.
+func timeDisplayFor(timeSlice model.TimeSlice) string {
+	startHour := timeSlice.Slice / 4
+	startMinute := (timeSlice.Slice % 4)
+	endHour := startHour
+	if startMinute == 3 {
+		endHour = startHour + 1
+	}
+	startMinuteString := fmt.Sprint(startMinute * 15)
+	if startMinute == 0 {
+		startMinuteString = "00"
+	}
+	endMinute := ((timeSlice.Slice % 4) + 1)
+	if endMinute == 4 {
+		endMinute = 0
+	}
+	endMinuteString := fmt.Sprint(endMinute * 15)
+	if endMinute == 0 {
+		endMinuteString = "00"
+	}
+	return fmt.Sprintf("%d:%s - %d:%s %s", startHour, startMinuteString, endHour, endMinuteString, "")
+}
+
+// Sum any timeslices spent doing the specified activity during the displayed day
+// into human readable text e.g. 2h 15m
+// Return a blank string if there's no timeslices for the activity.
+func (ui *UI) timeInActivityText(activityID string) string {
+	timeSliceCount := 0
+	for _, timeslice := range ui.currentDay.TimeSlices {
+		if timeslice.ActivityID == activityID {
+			timeSliceCount++
+		}
+	}
+	return durationText(timeSliceCount)
+}
+
+// Sum any timeslices during the displayed day that were spent doing activities that
+// are no longer in the config into human readable text, blank if there are none
+func (ui *UI) timeInDeletedActivitiesText() string {
+	timeSliceCount := 0
+	for _, timeslice := range ui.currentDay.TimeSlices {
+		if timeslice.ActivityID != "" && ui.config.ActivityByID(timeslice.ActivityID).ID == "" {
+			timeSliceCount++
+		}
+	}
+	return durationText(timeSliceCount)
+}
+
+// Return the time in the number of time slices as human readable text e.g. 2h 15m,
+// or a blank string if there are no time slices
+func durationText(timeSliceCount int) string {
+	if timeSliceCount == 0 {
+		return ""
+	}
+	return model.SlicesText(timeSliceCount)
+}
+
+// The user finished their input, if they finished it with enter, attempt to parse it, otherwise reset the input
+func (ui *UI) inputComplete(key tcell.Key) {
+	if key == tcell.KeyEnter {
+		ui.rememberCommand(ui.commandInput.GetText())
+		ui.parseInput()
+	} else if key == tcell.KeyEscape {
+		ui.resetInput()
+		ui.clearSelection()
+	}
+}
+
+// Assign the specified activity to the time slices with the specified t#'s and persist the update
+func (ui *UI) assignTime(timeSliceIndexes []int, activityIndex int) {
+	if ui.validTimeSliceIndexes(timeSliceIndexes) {
+		ui.assignSlices(ui.slicesFor(timeSliceIndexes), activityIndex)
+	}
+}
+
+// Unassign activity from the time slices with the specified t#'s and persist the update
+func (ui *UI) unassignTime(timeSliceIndexes []int) {
+	if ui.validTimeSliceIndexes(timeSliceIndexes) {
+		ui.unassignSlices(ui.slicesFor(timeSliceIndexes))
+	}
+}
+
+// Assign the specified activity to the time slices with the specified indexes in the day,
+// displayed or not, and persist the update
+func (ui *UI) assignSlices(slices []int, activityIndex int) {
+
+	// Get the activity
+	activity := ui.config.ActiveActivities()[activityIndex-1]
+
+	// update the day's specified timeslices with the specified activity
+	for _, slice := range slices {
+		ui.currentDay.TimeSlices[slice].ActivityID = activity.ID
+		ui.currentDay.TimeSlices[slice].Auto = false // the user's entry overrides a rule
+	}
+
+	ui.syncUI()
+	ui.persist()
+}
+
+// Unassign activity from the time slices with the specified indexes in the day and persist the update
+func (ui *UI) unassignSlices(slices []int) {
+	// update the day's specified timeslices with no activity
+	for _, slice := range slices {
+		ui.currentDay.TimeSlices[slice].ActivityID = ""
+		ui.currentDay.TimeSlices[slice].Auto = false
+	}
+
+	ui.syncUI()
+	ui.persist()
+}
+
+// Set the note of the time slices with the specified indexes in the day and persist the update,
+// a blank note removes it
+func (ui *UI) noteSlices(slices []int, note string) {
+	// update the day's specified timeslices with the note
+	for _, slice := range slices {
+		ui.currentDay.TimeSlices[slice].Note = note
+	}
+
+	ui.syncUI()
+	ui.persist()
+}
+
+// Return true if every one of the time slice indexes, t1 to t<displayed>, is displayed,
+// otherwise show which isn't
+func (ui *UI) validTimeSliceIndexes(timeSliceIndexes []int) bool {
+	displayed := len(ui.timeSlices())
+	for _, timeSliceIndex := range timeSliceIndexes {
+		if timeSliceIndex < 1 || timeSliceIndex > displayed {
+			ui.showStatus(fmt.Sprintf("There's no time slice t%d, only t1 to t%d are shown", timeSliceIndex, displayed))
+			return false
+		}
+	}
+	return true
+}
+
+func (ui *UI) syncUI() {
+	if thisDay, err := ui.currentDay.Time(); err == nil {
+		ui.header.SetText(ui.headerText(thisDay))
+	}
+	// refresh the timeslices and activity display in the ui
+	ui.timeSliceList.SetText(ui.timeSliceText())
+	ui.activityList.SetText(ui.activityText())
+}
+
+// Add, rename, recolor, hide or show an activity, save the config, and refresh the UI, e.g.
+// activity add "Exercise" #33cc66, activity rename a3 "Board Games", activity color a3 ffc885,
+// activity hide a3, activity show "Board Games"
+func (ui *UI) activityCommand(args []string) {
+	if len(args) == 0 {
+		ui.showStatus("Try: activity add|rename|color|hide|show")
+		return
+	}
+	var activity model.Activity
+	var err error
+	switch strings.ToLower(args[0]) {
+	case "add":
+		if len(args) < 2 || len(args) > 3 {
+			ui.showStatus(`Try: activity add "Name" [#33cc66]`)
+			return
+		}
+		color := model.NextActivityColor(ui.config.Activities)
+		if len(args) == 3 {
+			color = args[2]
+		}
+		activity, err = ui.addActivity(args[1], color)
+	case "rename":
+		if len(args) != 3 {
+			ui.showStatus(`Try: activity rename a# "New Name"`)
+			return
+		}
+		activity, err = ui.updateReferencedActivity(args[1], func(activity *model.Activity) {
+			activity.Name = args[2]
+		})
+	case "color", "recolor":
+		if len(args) != 3 {
+			ui.showStatus("Try: activity color a# #33cc66")
+			return
+		}
+		activity, err = ui.updateReferencedActivity(args[1], func(activity *model.Activity) {
+			activity.Color = args[2]
+		})
+	case "hide", "show":
+		if len(args) != 2 {
+			ui.showStatus(fmt.Sprintf(`Try: activity %s a#|"Name"`, args[0]))
+			return
+		}
+		activity, err = ui.updateReferencedActivity(args[1], func(activity *model.Activity) {
+			activity.Active = strings.ToLower(args[0]) == "show"
+		})
+	default:
+		ui.showStatus("Try: activity add|rename|color|hide|show")
+		return
+	}
+	if err != nil {
+		ui.showError(err)
+		return
+	}
+	ui.syncUI()
+	ui.showStatus("Saved activity: " + activity.Name)
+}
+
+// Add a new active activity with the specified name and color to the profile and save it
+func (ui *UI) addActivity(name string, color string) (model.Activity, error) {
+	activity, err := model.NewActivity(name, color)
+	if err != nil {
+		return activity, err
+	}
+	ui.config.Activities = append(ui.config.Activities, activity)
+	return activity, ui.saveActivities()
+}
+
+// Update the activity the user referenced by its a# or name, and save it
+func (ui *UI) updateReferencedActivity(reference string, update func(activity *model.Activity)) (model.Activity, error) {
+	index, err := parse.ActivityIndexFor(reference, ui.config.Activities)
+	if err != nil {
+		return model.Activity{}, err
+	}
+	activity, err := model.UpdateActivity(ui.config.Activities, ui.config.Activities[index].ID, update)
+	if err != nil {
+		return activity, err
+	}
+	return activity, ui.saveActivities()
+}
+
+// Write the profile's activities back to the config file it came from
+func (ui *UI) saveActivities() error {
+	return storage.SaveActivities(ui.configFile, ui.profile, ui.config)
+}
+
+// Check the config file for changes at the specified interval until done is closed, when
+// the UI stops running, and reload it in the UI's event loop when it changes
+func (ui *UI) watchConfig(interval time.Duration, done <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	lastModified := ui.configModTime()
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			modified := ui.configModTime()
+			if !modified.Equal(lastModified) && !modified.IsZero() {
+				lastModified = modified
+				ui.app.QueueUpdateDraw(ui.reloadConfig)
+			}
+		}
+	}
+}
+
+// Tick the clock at the specified interval until done is closed, when the UI stops running,
+// and move the UI along with it in the UI's event loop
+func (ui *UI) watchClock(interval time.Duration, done <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			ui.app.QueueUpdateDraw(func() {
+				ui.clockTick(ui.clock.Now())
+			})
+		}
+	}
+}
+
+// Move the UI along with the clock while today is shown. The current time slice is
+// kept displayed if it was displayed before the tick, and at midnight the new day is
+// loaded and shown. Other days are left as they are. The timer is reloaded, as bt on
+// another machine may have stopped it, and the time slices it has completed are assigned
+// to its activity, outside the event loop, see tickTimer.
+func (ui *UI) clockTick(now time.Time) {
+	if !ui.tickingTimer {
+		ui.tickingTimer = true
+		go ui.tickTimer(ui.store, now)
+	}
+	previous := ui.lastTick
+	ui.lastTick = now
+	if ui.currentDay.Date != previous.Format(model.DateFormat) {
+		ui.syncUI()
+		return
+	}
+	_, following := ui.timeSliceIndexFor(model.SliceForTime(previous))
+	if now.Format(model.DateFormat) != previous.Format(model.DateFormat) {
+		loadedDay, err := ui.loadDay(now)
+		if err != nil {
+			// stay on the previous day, and roll over on the next tick
+			ui.lastTick = previous
+			ui.showError(err)
+			return
+		}
+		ui.currentDay = loadedDay
+		ui.selection = Selection{}
+		ui.viewport = model.ViewportForTime(now, ui.viewport.Size)
+		if err := ui.loadWeek(); err != nil {
+			ui.showError(err)
+		}
+	} else if _, shown := ui.timeSliceIndexFor(model.SliceForTime(now)); following && !shown {
+		ui.viewport = model.ViewportForTime(now, ui.viewport.Size)
+	}
+	ui.syncUI()
+}
+
+// Return the last modification time of the config file, zero if it can't be read
+// (e.g. in the middle of an editor replacing it)
+func (ui *UI) configModTime() time.Time {
+	info, err := os.Stat(ui.configFile)
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
+
+// Read and validate the config file, and swap it in for the current config if it's valid,
+// otherwise keep the current config and show what's wrong with the new one
+func (ui *UI) reloadConfig() {
+	previous := ui.config.Activities
+	conf, err := storage.ReadConfig(ui.configFile)
+	if err == nil {
+		err = ui.applyProfile(conf, model.ProfileDisplayName(ui.profile))
+	}
+	if err != nil {
+		ui.showError(fmt.Errorf("config not reloaded: %w", err))
+		return
+	}
+	if problems := model.ValidateConfig(conf); len(problems) > 0 {
+		ui.showStatus(fmt.Sprintf("Config reloaded with %d problem(s), see: bt config check", len(problems)))
+	} else {
+		ui.showStatus("Config reloaded")
+	}
+	if deleted := model.DeletedActivities(previous, ui.config.Activities); len(deleted) > 0 {
+		go ui.warnDeletedActivities(ui.store, deleted)
+	}
+}
+
+// Look for stored time spent doing the deleted activities, and warn the user that it's no longer
+// resolvable if there is any. Runs outside of the UI's event loop, since it reads every stored day.
+func (ui *UI) warnDeletedActivities(store storage.Store, deleted []model.Activity) {
+	ids := []string{}
+	for _, activity := range deleted {
+		ids = append(ids, activity.ID)
+	}
+	dayCounts, err := store.DaysWithActivities(ids)
+	if err != nil {
+		ui.app.QueueUpdateDraw(func() { ui.showError(err) })
+		return
+	}
+	ui.warnDeletedTime(deleted, dayCounts)
+}
+
+// Look for stored time spent doing activities that aren't in the config, deleted while bt wasn't
+// running, and warn the user about it as for warnDeletedActivities. They're only known by their ID.
+// Runs outside of the UI's event loop when the UI starts running.
+func (ui *UI) warnUnknownActivities(store storage.Store, activities []model.Activity) {
+	dayCounts, err := store.DaysWithActivities(nil)
+	if err != nil {
+		ui.app.QueueUpdateDraw(func() { ui.showError(err) })
+		return
+	}
+	unknown := []model.Activity{}
+	for id := range dayCounts {
+		unknown = append(unknown, model.Activity{ID: id, Name: "ID " + id})
+	}
+	unknown = model.DeletedActivities(unknown, activities)
+	sort.Slice(unknown, func(i, j int) bool { return unknown[i].ID < unknown[j].ID })
+	ui.warnDeletedTime(unknown, dayCounts)
+}
+
+// Warn the user about every one of the deleted activities that has time on stored days, in one
+// status message, from outside of the UI's event loop
+func (ui *UI) warnDeletedTime(deleted []model.Activity, dayCounts map[string]int) {
+	withTime := []model.Activity{}
+	for _, activity := range deleted {
+		if dayCounts[activity.ID] > 0 {
+			withTime = append(withTime, activity)
+		}
+	}
+	if len(withTime) == 0 {
+		return
+	}
+	warning := fmt.Sprintf("Warning: deleted activity %s has time on %d day(s), set it to \"active\": false instead",
+		withTime[0].Name, dayCounts[withTime[0].ID])
+	if len(withTime) > 1 {
+		names := []string{}
+		for _, activity := range withTime {
+			names = append(names, fmt.Sprintf("%s (%d day(s))", activity.Name, dayCounts[activity.ID]))
+		}
+		warning = fmt.Sprintf("Warning: deleted activities %s have time, set them to \"active\": false instead",
+			strings.Join(names, ", "))
+	}
+	ui.app.QueueUpdateDraw(func() { ui.showStatus(warning) })
+}
+
+// Switch to the named profile from the config file, e.g. profile work
+func (ui *UI) switchProfile(name string) {
+	if name == "" {
+		ui.showStatus(fmt.Sprintf("Profile: %s (of %s)", model.ProfileDisplayName(ui.profile),
+			strings.Join(model.ProfileNames(ui.config), ", ")))
+		return
+	}
+	conf, err := storage.ReadConfig(ui.configFile)
+	if err == nil {
+		err = ui.applyProfile(conf, name)
+	}
+	if err != nil {
+		ui.showError(err)
+		return
+	}
+	ui.showStatus("Switched to the " + model.ProfileDisplayName(ui.profile) + " profile")
+}
+
+// Use the named profile of the config for the UI, reconnecting to storage and reloading the
+// day being shown and the timer if they've changed. The current profile, and its store, day and
+// timer, are kept if the new one has errors or its day or timer can't be loaded, so the current
+// day is never saved to the new profile's store.
+func (ui *UI) applyProfile(conf model.Config, name string) error {
+	name, err := model.ResolveProfileName(conf, name)
+	if err != nil {
+		return err
+	}
+	profileConf := model.ProfileConfig(conf, name)
+	if err := model.ProfileProblem(profileConf, name); err != nil {
+		return err
+	}
+
+	if profileConf.ProjectID == ui.config.ProjectID && profileConf.UserID == ui.config.UserID {
+		ui.config, ui.profile = profileConf, name
+		if err := ui.loadWeek(); err != nil {
+			// The activities' weekly goals may have changed
+			ui.showError(err)
+		}
+		ui.syncUI()
+		return nil
+	}
+
+	// The stored data is different, so load the day being shown and the timer from the new
+	// store before using it
+	current, err := ui.currentDay.Time()
+	if err != nil {
+		return err
+	}
+	store, err := ui.connect(profileConf, name)
+	if err != nil {
+		return err
+	}
+	day, err := ui.loadDayFrom(store, profileConf, current)
+	if err != nil {
+		store.Close()
+		return err
+	}
+	timer, err := store.LoadTimer()
+	if err != nil {
+		store.Close()
+		return err
+	}
+	ui.store.Close()
+	ui.config, ui.profile, ui.store = profileConf, name, store
+	ui.currentDay, ui.timer = day, timer
+	ui.selection = Selection{}
+	if err := ui.loadWeek(); err != nil {
+		ui.showError(err)
+	}
+	ui.syncUI()
+	return nil
+}
+
+// Persist the currently displayed day's timeslices
+func (ui *UI) persist() {
+	if err := ui.store.SaveDay(ui.currentDay); err != nil {
+		ui.showError(err)
+		return
+	}
+	ui.showStatus("")
+}
+
+// Display the next page of time slices, after the last one displayed, and rerender
+func (ui *UI) timeForward() {
+	ui.viewport = ui.viewport.Next()
+	ui.syncUI()
+}
+
+// Display the prior page of time slices, before the first one displayed, and rerender
+func (ui *UI) timeBackward() {
+	ui.viewport = ui.viewport.Prior()
+	ui.syncUI()
+}
+
+// Move the displayed time slices by the specified number of slices, later in the day
+// for a positive number, earlier for a negative number, and rerender
+func (ui *UI) scrollTime(slices int) {
+	ui.viewport = ui.viewport.Scroll(slices)
+	ui.syncUI()
+}
+
+// Display the time slices starting with the specified time slice of the day and rerender
+func (ui *UI) jumpToTime(slice int) {
+	ui.viewport = model.ViewportStartingAt(slice, ui.viewport.Size)
+	ui.syncUI()
+}
+
+// Handle navigation, history and completion keys pressed while the command input has focus.
+// Keys that would otherwise edit the input only navigate when the input is empty, and keys
+// that choose a completion are left to the input while the completions are shown.
+func (ui *UI) navigationKeys(event *tcell.EventKey) *tcell.EventKey {
+	if ui.searching {
+		return ui.historySearchKeys(event)
+	}
+	empty := ui.commandInput.GetText() == ""
+	switch event.Key() {
+	case tcell.KeyUp, tcell.KeyDown:
+		if ui.completing {
+			return event
+		}
+		offset := 1
+		if event.Key() == tcell.KeyUp {
+			offset = -1
+		}
+		if event.Modifiers()&tcell.ModShift != 0 {
+			ui.scrollTime(offset)
+		} else {
+			ui.recallHistory(offset)
+		}
+	case tcell.KeyPgUp:
+		ui.timeBackward()
+	case tcell.KeyPgDn:
+		ui.timeForward()
+	case tcell.KeyCtrlR:
+		ui.searchHistory()
+	case tcell.KeyEnter, tcell.KeyEscape:
+		ui.completing = false // the input closes the completions, or finishes
+		return event
+	case tcell.KeyTab:
+		if ui.completing {
+			return event
+		} else if empty {
+			ui.focusTimeSlices(false)
+		} else {
+			ui.completeCommand()
+		}
+	case tcell.KeyHome:
+		if !empty {
+			return event
+		}
+		ui.jumpToTime(0)
+	case tcell.KeyEnd:
+		if !empty {
+			return event
+		}
+		ui.jumpToTime(96)
+	case tcell.KeyRune:
+		if !empty {
+			return event
+		}
+		switch r := event.Rune(); {
+		case r == 'j':
+			ui.scrollTime(1)
+		case r == 'k':
+			ui.scrollTime(-1)
+		case r == 'v':
+			ui.focusTimeSlices(true)
+		case r == '?':
+			ui.showHelp()
+		case r >= '1' && r <= '9' && ui.selection.active:
+			ui.assignSelection(int(r - '0'))
+		default:
+			return event
+		}
+	default:
+		return event
+	}
+	return nil
+}
+
+// Parse the current day, increment it by one, and reset the UI
+func (ui *UI) dayForward() {
+	current, err := ui.currentDay.Time()
+	if err != nil {
+		ui.showError(err)
+		return
+	}
+	ui.resetForDay(current.AddDate(0, 0, 1), ui.viewport)
+}
+
+// Parse the current day, decrement it by one, and reset the UI
+func (ui *UI) dayBackward() {
+	current, err := ui.currentDay.Time()
+	if err != nil {
+		ui.showError(err)
+		return
+	}
+	ui.resetForDay(current.AddDate(0, 0, -1), ui.viewport)
+}
+
+// Set the current day to today and reset the UI
+func (ui *UI) dayTodayTimeNow() {
+	now := ui.clock.Now()
+	// The timer may have been started or stopped on another machine
+	if err := ui.loadTimer(); err != nil {
+		ui.showError(fmt.Errorf("%w (try again)", err))
+		return
+	}
+	// Set the day to today, with the time slices ending at the current time
+	ui.resetForDay(now, model.ViewportForTime(now, ui.viewport.Size))
+}
+
+// Set the current day to yesterday and reset the UI. Yesterday is the prior calendar day,
+// not 24h ago, which is a different day after a daylight saving time change.
+func (ui *UI) dayYesterday() {
+	ui.resetForDay(ui.clock.Now().AddDate(0, 0, -1), ui.viewport)
+}
+
+// Given a specific timestamp, load the stored data for that day and reset the UI to show
+// the viewport of it. If the day can't be loaded, the UI stays on the current day and
+// viewport and shows the error, so the user can retry the same command.
+func (ui *UI) resetForDay(day time.Time, viewport model.Viewport) {
+	loadedDay, err := ui.loadDay(day)
+	if err != nil {
+		ui.showError(fmt.Errorf("%w (try again)", err))
+		return
+	}
+	ui.showStatus("")
+	ui.currentDay = loadedDay
+	ui.selection = Selection{}
+	ui.viewport = viewport
+	if err := ui.loadWeek(); err != nil {
+		ui.showError(err)
+	}
+	ui.syncUI()
+}
@@ -0,0 +1,777 @@
+package tui
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	_ "time/tzdata" // so the DST test cases don't depend on the system's time zone database
+
+	"github.com/gdamore/tcell/v2"
+
+	"github.com/seven-serverless-projects/bt/model"
+	"github.com/seven-serverless-projects/bt/storage"
+)
+
+const (
+	screenWidth  = 100
+	screenHeight = 30 // 12 time slices are displayed
+	waitTimeout  = 2 * time.Second
+)
+
+// A clock that's always at the time it's set to
+type testClock struct {
+	now time.Time
+}
+
+func (clock *testClock) Now() time.Time {
+	return clock.now
+}
+
+// A store of days in memory that can be made to fail to load them
+type failingStore struct {
+	*storage.MemoryStore
+	fail bool
+}
+
+func (store *failingStore) LoadDay(forDay time.Time) (model.Day, error) {
+	if store.fail {
+		return model.Day{}, errors.New("unable to read data")
+	}
+	return store.MemoryStore.LoadDay(forDay)
+}
+
+// A UI running on a simulated screen, driven by a test
+type uiTest struct {
+	t      *testing.T
+	ui     *UI
+	screen tcell.SimulationScreen
+}
+
+// Return the activities of the UI under test
+func testActivities() []model.Activity {
+	return []model.Activity{
+		{ID: "1", Name: "Sleeping", Color: "08b4ff", Active: true},
+		{ID: "2", Name: "Writing", Color: "ff7bee", Active: true},
+		{ID: "3", Name: "Reading", Color: "abfff7", Active: false}}
+}
+
+// Start the UI on a simulated screen, showing the day of the specified time, stopping it when the test ends
+func startTestUI(t *testing.T, now time.Time, store storage.Store) *uiTest {
+	return startTestUIWithProfile(t, now, store, model.Profile{Activities: testActivities()})
+}
+
+// Start the UI on a simulated screen with the profile's activities and templates, see startTestUI
+func startTestUIWithProfile(t *testing.T, now time.Time, store storage.Store, profile model.Profile) *uiTest {
+	screen := tcell.NewSimulationScreen("UTF-8")
+	ui, err := New(Options{
+		Config:     model.Config{Profile: profile},
+		ConfigFile: filepath.Join(t.TempDir(), "config.json"),
+		DataDir:    t.TempDir(),
+		Store:      store,
+		Clock:      &testClock{now},
+		Screen:     screen,
+	})
+	if err != nil {
+		t.Fatalf("Test: UI FAIL - unable to start %v", err)
+	}
+	screen.SetSize(screenWidth, screenHeight)
+	done := make(chan error)
+	go func() { done <- ui.Run() }()
+	t.Cleanup(func() {
+		ui.Stop()
+		<-done
+	})
+	test := &uiTest{t, ui, screen}
+	test.waitForText("t12 — ")
+	return test
+}
+
+// Return the text drawn on the screen, a line per row
+func (test *uiTest) text() string {
+	var text string
+	test.ui.app.QueueUpdate(func() { text = test.screenText() })
+	return text
+}
+
+// Draw the screen and return its text, from within the UI's event loop
+func (test *uiTest) screenText() string {
+	test.ui.app.ForceDraw()
+	cells, width, _ := test.screen.GetContents()
+	var text strings.Builder
+	for i, cell := range cells {
+		if len(cell.Runes) > 0 {
+			text.WriteRune(cell.Runes[0])
+		} else {
+			text.WriteRune(' ')
+		}
+		if (i+1)%width == 0 {
+			text.WriteRune('\n')
+		}
+	}
+	return text.String()
+}
+
+// Return the line of the screen containing the text, blank if there isn't one
+func (test *uiTest) line(text string) string {
+	for _, line := range strings.Split(test.text(), "\n") {
+		if strings.Contains(line, text) {
+			return line
+		}
+	}
+	return ""
+}
+
+// Wait for the condition to be true in the UI's event loop, failing the test if it never is
+func (test *uiTest) waitFor(description string, condition func() bool) {
+	test.t.Helper()
+	for deadline := time.Now().Add(waitTimeout); time.Now().Before(deadline); time.Sleep(5 * time.Millisecond) {
+		met := false
+		test.ui.app.QueueUpdate(func() { met = condition() })
+		if met {
+			return
+		}
+	}
+	test.t.Fatalf("Test: UI FAIL - timed out waiting for %s, the screen is:\n%s", description, test.text())
+}
+
+// Wait for the text to be drawn on the screen
+func (test *uiTest) waitForText(text string) {
+	test.t.Helper()
+	test.waitFor(text, func() bool { return strings.Contains(test.screenText(), text) })
+}
+
+// Type the command into the command input and press Enter, waiting for it to be run. The keys
+// wait for room in the screen's event queue, which drops them when it's full.
+func (test *uiTest) typeCommand(command string) {
+	test.t.Helper()
+	for _, r := range command {
+		test.screen.PostEventWait(tcell.NewEventKey(tcell.KeyRune, r, tcell.ModNone))
+	}
+	test.waitFor("typing "+command, func() bool { return test.ui.commandInput.GetText() == command })
+	test.screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	test.waitFor("running "+command, func() bool { return test.ui.commandInput.GetText() == "" })
+}
+
+// Press the key, waiting for the text to be drawn on the screen
+func (test *uiTest) pressKey(key tcell.Key, r rune, text string) {
+	test.t.Helper()
+	test.screen.InjectKey(key, r, tcell.ModNone)
+	test.waitForText(text)
+}
+
+// Return the stored day of the specified time
+func loadTestDay(t *testing.T, store storage.Store, forDay time.Time) model.Day {
+	day, err := store.LoadDay(forDay)
+	if err != nil {
+		t.Fatalf("Test: UI FAIL - unable to load the stored day %v", err)
+	}
+	return day
+}
+
+// TestRender - test the day, time slices and activities are drawn on the screen
+func TestRender(t *testing.T) {
+	t.Log("Test: rendering...")
+	now := time.Date(2020, 10, 26, 10, 5, 0, 0, time.Local)
+	test := startTestUI(t, now, storage.NewMemoryStore())
+
+	text := test.text()
+	for _, expected := range []string{"Monday, October 26, 2020", "t1 — 7:15 - 7:30", "a1 — Sleeping", "a2 — Writing", "Command:"} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("Test: render FAIL - %q isn't drawn:\n%s", expected, text)
+		}
+	}
+	if strings.Contains(text, "Reading") || strings.Contains(text, "t13 — ") {
+		t.Errorf("Test: render FAIL - unexpected text drawn:\n%s", text)
+	}
+	if line := test.line("t12 — "); !strings.Contains(line, "10:00 - 10:15") || !strings.Contains(line, "◂ now") {
+		t.Errorf("Test: render FAIL - current time slice %q", line)
+	}
+}
+
+// TestAssignTime - test assigning and unassigning activities to time slices with commands
+func TestAssignTime(t *testing.T) {
+	t.Log("Test: assigning time...")
+	now := time.Date(2020, 10, 26, 10, 5, 0, 0, time.Local)
+	store := storage.NewMemoryStore()
+	test := startTestUI(t, now, store)
+
+	test.typeCommand("t12 a1")
+	if day := loadTestDay(t, store, now); day.TimeSlices[40].ActivityID != "1" {
+		t.Errorf("Test: assign FAIL - t12 a1 stored %q", day.TimeSlices[40].ActivityID)
+	}
+	if line := test.line("t12 — "); !strings.Contains(line, "Sleeping") {
+		t.Errorf("Test: assign FAIL - t12 a1 drawn %q", line)
+	}
+	test.typeCommand("t1-t4 a2")
+	test.typeCommand("u t2, t3")
+	test.typeCommand("t1 a9") // there's no a9, so nothing changes
+	expected := map[int]string{29: "2", 30: "", 31: "", 32: "2", 40: "1"}
+	day := loadTestDay(t, store, now)
+	for slice, activityID := range expected {
+		if day.TimeSlices[slice].ActivityID != activityID {
+			t.Errorf("Test: assign FAIL - slice %d stored %q", slice, day.TimeSlices[slice].ActivityID)
+		}
+	}
+	if line := test.line("a2 — "); !strings.Contains(line, "Writing — 30m") {
+		t.Errorf("Test: assign FAIL - time in activity drawn %q", line)
+	}
+	if line := test.line("a1 — "); !strings.Contains(line, "Sleeping — 15m") {
+		t.Errorf("Test: assign FAIL - time in activity drawn %q", line)
+	}
+}
+
+// TestNavigation - test moving through the time slices of the day with commands and keys
+func TestNavigation(t *testing.T) {
+	t.Log("Test: navigation...")
+	now := time.Date(2020, 10, 26, 10, 5, 0, 0, time.Local)
+	test := startTestUI(t, now, storage.NewMemoryStore())
+
+	type testCase struct {
+		command string
+		first   string // the time slice drawn as t1
+		last    string // the time slice drawn as t12
+	}
+
+	testCases := []testCase{
+		{"g9", "9:00 - 9:15", "11:45 - 12:00"},
+		{"goto 14:30", "14:30 - 14:45", "17:15 - 17:30"},
+		{"home", "0:00 - 0:15", "2:45 - 3:00"},
+		{"+", "3:00 - 3:15", "5:45 - 6:00"}, // pages don't overlap
+		{"+", "6:00 - 6:15", "8:45 - 9:00"},
+		{"-", "3:00 - 3:15", "5:45 - 6:00"},
+		{"-", "0:00 - 0:15", "2:45 - 3:00"},
+		{"-", "0:00 - 0:15", "2:45 - 3:00"},
+		{"g1", "1:00 - 1:15", "3:45 - 4:00"},
+		{"-", "0:00 - 0:15", "2:45 - 3:00"},
+		{"end", "21:00 - 21:15", "23:45 - 24:00"},
+		{"+", "21:00 - 21:15", "23:45 - 24:00"},
+		{"-", "18:00 - 18:15", "20:45 - 21:00"},
+		{"g22", "21:00 - 21:15", "23:45 - 24:00"},
+		{"t", "7:15 - 7:30", "10:00 - 10:15"}}
+	for i, testCase := range testCases {
+		test.typeCommand(testCase.command)
+		if first := test.line("t1 — "); !strings.Contains(first, testCase.first) {
+			t.Errorf("Test: navigation FAIL - t1 is %q in test case %d", first, i+1)
+		} else if last := test.line("t12 — "); !strings.Contains(last, testCase.last) {
+			t.Errorf("Test: navigation FAIL - t12 is %q in test case %d", last, i+1)
+		}
+	}
+
+	test.typeCommand("home")
+	test.pressKey(tcell.KeyRune, 'j', "t1 — 0:15 - 0:30")
+	test.pressKey(tcell.KeyPgDn, 0, "t1 — 3:15 - 3:30")
+	test.pressKey(tcell.KeyPgUp, 0, "t1 — 0:15 - 0:30")
+	test.pressKey(tcell.KeyRune, 'k', "t1 — 0:00 - 0:15")
+	test.pressKey(tcell.KeyEnd, 0, "t12 — 23:45 - 24:00")
+	test.typeCommand("day")
+	test.waitForText("t93-t96 23:00")
+	test.typeCommand("day")
+	test.waitForText("t12 — 23:45 - 24:00")
+}
+
+// TestSelectPastViewport - test a selection extended past the displayed time slices with the
+// keyboard assigns every selected time slice, including those scrolled out of view
+func TestSelectPastViewport(t *testing.T) {
+	t.Log("Test: selecting past the viewport...")
+	now := time.Date(2020, 10, 26, 10, 5, 0, 0, time.Local)
+	store := storage.NewMemoryStore()
+	test := startTestUI(t, now, store)
+
+	test.typeCommand("home")
+	test.pressKey(tcell.KeyTab, 0, "v select, j/k move")
+	test.screen.PostEventWait(tcell.NewEventKey(tcell.KeyRune, 'v', tcell.ModNone))
+	for i := 0; i < 20; i++ {
+		test.screen.PostEventWait(tcell.NewEventKey(tcell.KeyRune, 'j', tcell.ModNone))
+	}
+	test.waitForText("t1 — 2:15 - 2:30") // the cursor on 5:00 scrolled the first 9 time slices out of view
+	test.screen.PostEventWait(tcell.NewEventKey(tcell.KeyRune, '2', tcell.ModNone))
+	test.waitFor("assigning the selection", func() bool { return !test.ui.selection.active })
+
+	day := loadTestDay(t, store, now)
+	for slice := 0; slice <= 21; slice++ {
+		expected := "2"
+		if slice == 21 {
+			expected = ""
+		}
+		if day.TimeSlices[slice].ActivityID != expected {
+			t.Errorf("Test: selecting FAIL - slice %d stored %q", slice, day.TimeSlices[slice].ActivityID)
+		}
+	}
+}
+
+// TestResetForDay - test moving between days, loading each from the store
+func TestResetForDay(t *testing.T) {
+	t.Log("Test: changing days...")
+	now := time.Date(2020, 10, 26, 10, 5, 0, 0, time.Local)
+	store := &failingStore{MemoryStore: storage.NewMemoryStore()}
+	sunday := loadTestDay(t, store, now.AddDate(0, 0, -1))
+	sunday.TimeSlices[40].ActivityID = "2"
+	store.SaveDay(sunday)
+	test := startTestUI(t, now, store)
+
+	type testCase struct {
+		command string
+		header  string
+		now     bool // the current time slice is shown
+	}
+
+	testCases := []testCase{
+		{"p", "Sunday, October 25, 2020", false},
+		{"n", "Monday, October 26, 2020", true},
+		{"y", "Sunday, October 25, 2020", false},
+		{"t", "Monday, October 26, 2020", true},
+		{"next", "Tuesday, October 27, 2020", false},
+		{"today", "Monday, October 26, 2020", true}}
+	for i, testCase := range testCases {
+		test.typeCommand(testCase.command)
+		text := test.text()
+		if !strings.Contains(text, testCase.header) {
+			t.Errorf("Test: change day FAIL - %s isn't shown in test case %d", testCase.header, i+1)
+		} else if strings.Contains(text, "◂ now") != testCase.now {
+			t.Errorf("Test: change day FAIL - current time slice in test case %d", i+1)
+		} else if sunday := strings.Contains(test.line("t12 — "), "Writing"); sunday != strings.HasPrefix(testCase.header, "Sunday") {
+			t.Errorf("Test: change day FAIL - stored day not loaded in test case %d", i+1)
+		}
+	}
+
+	// A day that can't be loaded leaves the day shown, so the command can be tried again
+	test.ui.app.QueueUpdate(func() { store.fail = true })
+	test.typeCommand("n")
+	test.waitForText("(try again)")
+	if !strings.Contains(test.text(), "Monday, October 26, 2020") {
+		t.Errorf("Test: change day FAIL - day changed without loading")
+	}
+	test.typeCommand("g2")
+	test.typeCommand("t")
+	test.waitForText("(try again)")
+	if first := test.line("t1 — "); !strings.Contains(first, "2:00 - 2:15") {
+		t.Errorf("Test: change day FAIL - time slices moved without loading, t1 is %q", first)
+	}
+	test.ui.app.QueueUpdate(func() { store.fail = false })
+	test.typeCommand("n")
+	test.waitForText("Tuesday, October 27, 2020")
+	test.waitForText("t1 — 2:00 - 2:15")
+	test.typeCommand("t")
+	test.waitForText("t12 — 10:00 - 10:15")
+}
+
+// TestSwitchProfileFails - test switching to a profile whose day can't be loaded keeps the
+// current profile, so its day is never saved to the other profile's store
+func TestSwitchProfileFails(t *testing.T) {
+	t.Log("Test: switching profile fails...")
+	now := time.Date(2020, 10, 26, 10, 5, 0, 0, time.Local)
+	personalStore := storage.NewMemoryStore()
+	workStore := &failingStore{storage.NewMemoryStore(), true}
+	personal := model.Profile{Name: "Albert", Email: "albert@example.com", UserID: "0c5a3d0e-5d1e-4c49-9a3e-2b1f5f7e6a11",
+		ProjectID: "personal-project", Activities: testActivities()}
+	test := startTestUIWithProfile(t, now, personalStore, personal)
+	conf := model.Config{Profile: personal, Profiles: map[string]model.Profile{"work": {ProjectID: "work-project",
+		Activities: []model.Activity{{ID: "9", Name: "Meetings", Color: "fffbaa", Active: true}}}}}
+	if err := storage.WriteConfig(conf, test.ui.configFile); err != nil {
+		t.Fatalf("Test: switching profile FAIL - unable to write the config %v", err)
+	}
+	test.ui.app.QueueUpdate(func() {
+		test.ui.connect = func(conf model.Config, profile string) (storage.Store, error) { return workStore, nil }
+	})
+
+	test.typeCommand("profile work")
+	test.waitForText("unable to read data")
+	test.typeCommand("t12 a1")
+	workStore.fail = false
+	if day := loadTestDay(t, workStore, now); day.TimeSlices[40].ActivityID != "" {
+		t.Errorf("Test: switching profile FAIL - the personal day was saved to the work store")
+	}
+	if day := loadTestDay(t, personalStore, now); day.TimeSlices[40].ActivityID != "1" {
+		t.Errorf("Test: switching profile FAIL - the personal profile wasn't kept")
+	}
+
+	test.typeCommand("profile work")
+	test.waitForText("Switched to the work profile")
+	test.typeCommand("t12 a1")
+	if day := loadTestDay(t, workStore, now); day.TimeSlices[40].ActivityID != "9" {
+		t.Errorf("Test: switching profile FAIL - work time stored %q", day.TimeSlices[40].ActivityID)
+	}
+	if day := loadTestDay(t, personalStore, now); day.TimeSlices[40].ActivityID != "1" {
+		t.Errorf("Test: switching profile FAIL - work time stored in the personal store")
+	}
+}
+
+// TestDeletedActivities - test every deleted activity with stored time is warned about, whether
+// it's deleted while bt is running or before it starts
+func TestDeletedActivities(t *testing.T) {
+	t.Log("Test: deleted activities...")
+	now := time.Date(2020, 10, 26, 10, 5, 0, 0, time.Local)
+	store := storage.NewMemoryStore()
+	for daysAgo, activityIDs := range [][]string{{"1", "7"}, {"2", "8"}, {"8"}} {
+		day := model.NewDay(now.AddDate(0, 0, -daysAgo))
+		for slice, activityID := range activityIDs {
+			day.TimeSlices[slice].ActivityID = activityID
+		}
+		store.SaveDay(day)
+	}
+	profile := model.Profile{Name: "Albert", Email: "albert@example.com", UserID: "0c5a3d0e-5d1e-4c49-9a3e-2b1f5f7e6a11",
+		ProjectID: "personal-project", Activities: testActivities()}
+	test := startTestUIWithProfile(t, now, store, profile)
+	status := func(text string) func() bool {
+		return func() bool { return strings.Contains(test.ui.status.GetText(true), text) }
+	}
+	test.waitFor("the deleted activities warning", status("deleted activities ID 7 (1 day(s)), ID 8 (2 day(s)) have time"))
+
+	profile.Activities = testActivities()[2:]
+	if err := storage.WriteConfig(model.Config{Profile: profile}, test.ui.configFile); err != nil {
+		t.Fatalf("Test: deleted activities FAIL - unable to write the config %v", err)
+	}
+	test.ui.app.QueueUpdate(test.ui.reloadConfig)
+	test.waitFor("the deleted activities warning", status("deleted activities Sleeping (1 day(s)), Writing (1 day(s)) have time"))
+}
+
+// TestClockTick - test the UI follows the clock to the next day at midnight
+func TestClockTick(t *testing.T) {
+	t.Log("Test: clock tick...")
+	now := time.Date(2020, 10, 26, 23, 50, 0, 0, time.Local)
+	test := startTestUI(t, now, storage.NewMemoryStore())
+
+	if line := test.line("t12 — "); !strings.Contains(line, "23:45 - 24:00") || !strings.Contains(line, "◂ now") {
+		t.Errorf("Test: clock tick FAIL - current time slice %q", line)
+	}
+	test.ui.app.QueueUpdate(func() { test.ui.clockTick(now.Add(15 * time.Minute)) })
+	test.waitForText("Tuesday, October 27, 2020")
+	if line := test.line("t1 — "); !strings.Contains(line, "0:00 - 0:15") || !strings.Contains(line, "◂ now") {
+		t.Errorf("Test: clock tick FAIL - current time slice after midnight %q", line)
+	}
+}
+
+// TestWatchingStops - test watching for changes stops when the UI stops running
+func TestWatchingStops(t *testing.T) {
+	t.Log("Test: watching stops...")
+	test := startTestUI(t, time.Date(2020, 10, 26, 10, 5, 0, 0, time.Local), storage.NewMemoryStore())
+
+	watchers := map[string]func(time.Duration, <-chan struct{}){
+		"the config file": test.ui.watchConfig,
+		"the clock":       test.ui.watchClock}
+	for name, watch := range watchers {
+		done := make(chan struct{})
+		stopped := make(chan struct{})
+		go func() {
+			watch(time.Millisecond, done)
+			close(stopped)
+		}()
+		close(done)
+		select {
+		case <-stopped:
+		case <-time.After(waitTimeout):
+			t.Errorf("Test: watching FAIL - still watching %s", name)
+		}
+	}
+}
+
+// TestYesterdayDST - test yesterday is the prior calendar day after clocks go forward,
+// when 24 hours ago is two days before
+func TestYesterdayDST(t *testing.T) {
+	t.Log("Test: yesterday after DST starts...")
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("Test: yesterday FAIL - time zone %v", err)
+	}
+	now := time.Date(2021, 3, 15, 0, 30, 0, 0, newYork)
+	test := startTestUI(t, now, storage.NewMemoryStore())
+
+	test.typeCommand("y")
+	if text := test.text(); !strings.Contains(text, "Sunday, March 14, 2021") {
+		t.Errorf("Test: yesterday FAIL - the screen is:\n%s", text)
+	}
+}
+
+// TestGoals - test the progress towards activities' goals is drawn under them, colored by
+// how close it is, with the time on the other days of the week counted for weekly goals
+func TestGoals(t *testing.T) {
+	t.Log("Test: goals...")
+	now := time.Date(2020, 10, 28, 10, 5, 0, 0, time.Local) // a Wednesday
+	store := storage.NewMemoryStore()
+	monday := loadTestDay(t, store, now.AddDate(0, 0, -2))
+	today := loadTestDay(t, store, now)
+	for slice := 0; slice < 24; slice++ {
+		monday.TimeSlices[slice+36].ActivityID = "2" // 6h of writing
+		today.TimeSlices[slice].ActivityID = "1"     // 6h of sleeping
+	}
+	store.SaveDay(monday)
+	store.SaveDay(today)
+	nextMonday := loadTestDay(t, store, now.AddDate(0, 0, 5))
+	nextMonday.TimeSlices[0].ActivityID = "2" // not in this week
+	store.SaveDay(nextMonday)
+	activities := testActivities()
+	activities[0].DailyGoal = "7h"
+	activities[1].WeeklyGoal = "10h"
+	activities[1].Limit = "1h"
+	test := startTestUIWithProfile(t, now, store, model.Profile{Activities: activities})
+
+	type testCase struct {
+		command string
+		lines   []string // drawn on the screen
+		bars    []string // the progress bars, in their colors
+	}
+
+	testCases := []testCase{
+		{"", []string{"▰▰▰▰▰▰▰▰▱▱ at least 7h a day", "▰▰▰▰▰▰▱▱▱▱ 6h, at least 10h a week", "▱▱▱▱▱▱▱▱▱▱ at most 1h a day"},
+			[]string{"[yellow]▰▰▰▰▰▰▰▰▱▱", "[gray]▰▰▰▰▰▰▱▱▱▱", "[gray]▱▱▱▱▱▱▱▱▱▱"}},
+		{"t1-t4 a2", []string{"▰▰▰▰▰▰▰▱▱▱ 7h, at least 10h a week", "▰▰▰▰▰▰▰▰▰▰ at most 1h a day"},
+			[]string{"[gray]▰▰▰▰▰▰▰▱▱▱", "[yellow]▰▰▰▰▰▰▰▰▰▰"}},
+		{"t5-t8 a1", []string{"▰▰▰▰▰▰▰▰▰▰ at least 7h a day"},
+			[]string{"[limegreen]▰▰▰▰▰▰▰▰▰▰"}},
+		{"t9-t12 a2", []string{"▰▰▰▰▰▰▰▰▰▰ at most 1h a day", "▰▰▰▰▰▰▰▰▱▱ 8h, at least 10h a week"},
+			[]string{"[red]▰▰▰▰▰▰▰▰▰▰", "[yellow]▰▰▰▰▰▰▰▰▱▱"}}}
+	for i, testCase := range testCases {
+		if testCase.command != "" {
+			test.typeCommand(testCase.command)
+		}
+		for _, line := range testCase.lines {
+			test.waitForText(line)
+		}
+		var activityText string
+		test.ui.app.QueueUpdate(func() { activityText = test.ui.activityText() })
+		for _, bar := range testCase.bars {
+			if !strings.Contains(activityText, bar) {
+				t.Errorf("Test: goals FAIL - %s isn't drawn in test case %d", bar, i+1)
+			}
+		}
+	}
+
+	// Clicking under an activity, on its goals, assigns it
+	test.ui.app.QueueUpdate(func() {
+		x, y, _, _ := test.ui.activityList.GetInnerRect()
+		for row, expected := range []int{1, 1, 1, 2, 2, 2, 2, 0} {
+			if index, onActivity := test.ui.activityAt(x+1, y+row); index != expected || onActivity != (expected > 0) {
+				t.Errorf("Test: goals FAIL - row %d is a%d", row, index)
+			}
+		}
+	})
+
+	// Another week doesn't count this week's time
+	test.typeCommand("next")
+	test.typeCommand("next")
+	test.typeCommand("next")
+	test.typeCommand("next")
+	test.typeCommand("next")
+	test.waitForText("▱▱▱▱▱▱▱▱▱▱ 15m, at least 10h a week")
+}
+
+// TestFillGaps - test showing the untracked time, and filling it without changing assigned time
+func TestFillGaps(t *testing.T) {
+	t.Log("Test: filling gaps...")
+	now := time.Date(2020, 10, 26, 10, 5, 0, 0, time.Local)
+	store := storage.NewMemoryStore()
+	test := startTestUI(t, now, store)
+
+	test.typeCommand("t12 a1")
+	test.typeCommand("gaps")
+	test.waitForText("10h untracked")
+	test.waitForText("0:00 - 10:00  10h")
+	test.pressKey(tcell.KeyEnter, 0, "Command:")
+
+	test.typeCommand("fill 9:00-11:00 a2")
+	test.waitForText("Filled 1h 45m with Writing")
+	test.typeCommand("fill a1") // today, only up to now
+	test.waitForText("Filled 9h with Sleeping")
+	day := loadTestDay(t, store, now)
+	for slice, activityID := range map[int]string{0: "1", 35: "1", 36: "2", 39: "2", 40: "1", 41: "2", 43: "2", 44: ""} {
+		if day.TimeSlices[slice].ActivityID != activityID {
+			t.Errorf("Test: fill FAIL - slice %d stored %q", slice, day.TimeSlices[slice].ActivityID)
+		}
+	}
+	test.typeCommand("fill a2")
+	test.waitForText("No untracked time to fill")
+	test.typeCommand("gaps")
+	test.waitForText("No untracked time")
+
+	test.typeCommand("p")
+	test.typeCommand("fill a2")
+	test.waitForText("Filled 24h with Writing")
+	test.typeCommand("n")
+	test.typeCommand("n")
+	test.typeCommand("fill a2") // tomorrow hasn't happened yet
+	test.waitForText("No untracked time to fill")
+}
+
+// TestTemplatesAndCopy - test applying a day template and copying another day, merging and overwriting
+func TestTemplatesAndCopy(t *testing.T) {
+	t.Log("Test: templates and copying days...")
+	now := time.Date(2020, 10, 26, 10, 5, 0, 0, time.Local)
+	store := storage.NewMemoryStore()
+	test := startTestUIWithProfile(t, now, store, model.Profile{Activities: testActivities(),
+		Templates: map[string][]model.TemplateEntry{"workday": {{Time: "0:00-7:00", Activity: "Sleeping"}, {Time: "9-17", Activity: "Writing"}}}})
+
+	test.typeCommand("t12 a1") // 10:00
+	test.typeCommand("apply Workday")
+	test.waitForText("Copied 14h 45m from the workday template")
+	day := loadTestDay(t, store, now)
+	if day.TimeSlices[0].ActivityID != "1" || day.TimeSlices[36].ActivityID != "2" || day.TimeSlices[40].ActivityID != "1" ||
+		day.TimeSlices[67].ActivityID != "2" || day.TimeSlices[68].ActivityID != "" {
+		t.Errorf("Test: templates FAIL - applied workday %v", day.TimeSlices[36:44])
+	}
+	test.typeCommand("apply workday overwrite")
+	test.waitForText("Copied 15m from the workday template")
+	test.typeCommand("apply holiday")
+	test.waitForText(`there's no template named "holiday"`)
+	test.typeCommand("apply workday sometimes")
+	test.waitForText("usage: apply TEMPLATE [merge|overwrite]")
+
+	test.typeCommand("n")
+	test.typeCommand("t8 a1") // 9:00
+	test.typeCommand("copy yesterday")
+	test.waitForText("Copied 14h 45m from 2020-10-26")
+	test.typeCommand("copy 2020-10-26 overwrite")
+	test.waitForText("Copied 15m from 2020-10-26")
+	test.typeCommand("copy 2020-10-26")
+	test.waitForText("Nothing to copy from 2020-10-26")
+	test.typeCommand("copy 2020-10-27")
+	test.waitForText("a day can't be copied into itself")
+	if tomorrow := loadTestDay(t, store, now.AddDate(0, 0, 1)); tomorrow.TimeSlices != loadTestDay(t, store, now).TimeSlices {
+		t.Errorf("Test: copying days FAIL - the copy doesn't match")
+	}
+}
+
+// TestRules - test the config's rules assign time to days when they're first shown, up to today,
+// and that the user's entries override them
+func TestRules(t *testing.T) {
+	t.Log("Test: rules...")
+	now := time.Date(2020, 10, 26, 10, 5, 0, 0, time.Local)
+	store := storage.NewMemoryStore()
+	saturday := model.NewDay(now.AddDate(0, 0, -2))
+	saturday.TimeSlices[0].ActivityID = "1"
+	store.SaveDay(saturday)
+	test := startTestUIWithProfile(t, now, store, model.Profile{Activities: testActivities(),
+		Rules: []model.Rule{{Days: "daily", Time: "9-10", Activity: "Writing"}}})
+
+	test.waitForText("t8 — 9:00 - 9:15  — Writing (auto)")
+	if loadTestDay(t, store, now).Stored {
+		t.Errorf("Test: rules FAIL - today was stored just by viewing it")
+	}
+	test.typeCommand("t8 a1")
+	test.waitForText("t8 — 9:00 - 9:15  — Sleeping")
+	test.typeCommand("u t9")
+	day := loadTestDay(t, store, now)
+	if day.TimeSlices[36].ActivityID != "1" || day.TimeSlices[36].Auto || day.TimeSlices[37].ActivityID != "" ||
+		day.TimeSlices[37].Auto || day.TimeSlices[38].ActivityID != "2" || !day.TimeSlices[38].Auto {
+		t.Errorf("Test: rules FAIL - the user's entries didn't override the auto time slices")
+	}
+
+	test.typeCommand("p")
+	test.waitForText("Sunday, October 25, 2020")
+	test.waitForText("t8 — 9:00 - 9:15  — Writing (auto)")
+	if loadTestDay(t, store, now.AddDate(0, 0, -1)).Stored {
+		t.Errorf("Test: rules FAIL - a past day was stored just by viewing it")
+	}
+	test.typeCommand("p")
+	test.waitForText("Saturday, October 24, 2020")
+	if strings.Contains(test.text(), "(auto)") || loadTestDay(t, store, now.AddDate(0, 0, -2)).TimeSlices[36].ActivityID != "" {
+		t.Errorf("Test: rules FAIL - a stored day was changed")
+	}
+	test.typeCommand("t")
+	test.typeCommand("n")
+	test.waitForText("Tuesday, October 27, 2020")
+	test.typeCommand("t1 a1") // the first save of tomorrow saves the rules' time with it
+	tomorrow := loadTestDay(t, store, now.AddDate(0, 0, 1))
+	if tomorrow.TimeSlices[29].ActivityID != "1" || tomorrow.TimeSlices[36].ActivityID != "2" || !tomorrow.TimeSlices[36].Auto {
+		t.Errorf("Test: rules FAIL - tomorrow stored without the auto time slices")
+	}
+	restarted := startTestUIWithProfile(t, now.AddDate(0, 0, 1), store, model.Profile{Activities: testActivities(),
+		Rules: []model.Rule{{Days: "daily", Time: "9-10", Activity: "Writing"}}})
+	restarted.waitForText("Tuesday, October 27, 2020")
+	restarted.waitForText("t8 — 9:00 - 9:15  — Writing (auto)")
+}
+
+// TestTimer - test timing an activity live assigns the time slices it completes, and carries on
+// from the stored timer after a restart
+func TestTimer(t *testing.T) {
+	t.Log("Test: timer...")
+	now := time.Date(2020, 10, 26, 10, 5, 0, 0, time.Local)
+	store := storage.NewMemoryStore()
+	test := startTestUI(t, now, store)
+
+	test.typeCommand("stop")
+	test.waitForText("The timer isn't running")
+	test.typeCommand("start a2")
+	test.waitForText("Started timing Writing")
+	test.waitForText("▶ Writing 0m")
+	test.ui.app.QueueUpdate(func() {
+		test.ui.clock.(*testClock).now = now.Add(15 * time.Minute)
+		test.ui.clockTick(now.Add(15 * time.Minute))
+	})
+	test.waitForText("▶ Writing 15m")
+	test.waitForText("t11 — 10:00 - 10:15  — Writing")
+	test.waitFor("the stored timer", func() bool {
+		timer, _ := store.LoadTimer()
+		return timer.ActivityID == "2" && timer.Through.Equal(now.Add(10*time.Minute))
+	})
+
+	// bt restarts, having missed a time slice
+	restarted := startTestUI(t, now.Add(35*time.Minute), store)
+	restarted.waitForText("▶ Writing 35m")
+	restarted.typeCommand("stop")
+	restarted.waitForText("Stopped timing Writing after 35m")
+	if strings.Contains(restarted.text(), "▶") {
+		t.Errorf("Test: timer FAIL - stopped timer shown")
+	}
+	day := loadTestDay(t, store, now)
+	if day.TimeSlices[39].ActivityID != "" || day.TimeSlices[40].ActivityID != "2" || day.TimeSlices[41].ActivityID != "2" ||
+		day.TimeSlices[42].ActivityID != "2" || day.TimeSlices[43].ActivityID != "" {
+		t.Errorf("Test: timer FAIL - assigned time slices %v", day.TimeSlices[39:44])
+	}
+	if timer, _ := store.LoadTimer(); timer.Running() {
+		t.Errorf("Test: timer FAIL - stopped timer stored %v", timer)
+	}
+
+	// bt on another machine stops the timer
+	restarted.typeCommand("start a1")
+	restarted.waitForText("▶ Sleeping 0m")
+	if err := store.SaveTimer(model.Timer{}); err != nil {
+		t.Fatalf("Test: timer FAIL - unable to stop the stored timer %v", err)
+	}
+	restarted.ui.app.QueueUpdate(func() { restarted.ui.clockTick(now.Add(50 * time.Minute)) })
+	restarted.waitFor("the stopped timer", func() bool { return !restarted.ui.timer.Running() })
+	if timer, _ := store.LoadTimer(); timer.Running() {
+		t.Errorf("Test: timer FAIL - timer stopped on another machine stored %v", timer)
+	}
+
+	// bt on another machine stops the timer, and then it's stopped here too
+	restarted.typeCommand("start a1")
+	restarted.waitForText("▶ Sleeping 0m")
+	if err := store.SaveTimer(model.Timer{}); err != nil {
+		t.Fatalf("Test: timer FAIL - unable to stop the stored timer %v", err)
+	}
+	restarted.ui.app.QueueUpdate(func() { restarted.ui.clock.(*testClock).now = now.Add(80 * time.Minute) })
+	restarted.typeCommand("stop")
+	restarted.waitForText("The timer isn't running")
+	if day := loadTestDay(t, store, now); day.TimeSlices[43].ActivityID != "" {
+		t.Errorf("Test: timer FAIL - a timer stopped on another machine assigned time again")
+	}
+}
+
+// A store whose timer can't be read until it's released, like a hung network
+type slowTimerStore struct {
+	*storage.MemoryStore
+	release chan struct{}
+}
+
+func (store *slowTimerStore) LoadTimer() (model.Timer, error) {
+	<-store.release
+	return store.MemoryStore.LoadTimer()
+}
+
+// TestSlowTimerStore - test the clock ticking doesn't freeze the UI while the timer is read
+func TestSlowTimerStore(t *testing.T) {
+	t.Log("Test: slow timer store...")
+	now := time.Date(2020, 10, 26, 10, 5, 0, 0, time.Local)
+	store := &slowTimerStore{storage.NewMemoryStore(), make(chan struct{})}
+	close(store.release)
+	test := startTestUI(t, now, store)
+	store.release = make(chan struct{})
+
+	test.ui.app.QueueUpdate(func() { test.ui.clockTick(now.Add(15 * time.Minute)) })
+	test.typeCommand("t12 a1")
+	test.waitForText("a1 — Sleeping — 15m")
+	close(store.release)
+	test.waitFor("the timer tick", func() bool { return !test.ui.tickingTimer })
+}